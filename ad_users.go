@@ -1,7 +1,7 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -11,19 +11,6 @@ import (
 // GetADUser fetches a single AD user by their Object ID (SID).
 func (c *Client) GetADUser(objectID string) (*ADUser, error) {
 	url := c.baseURL.JoinPath("/api/v2/users/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get ad user failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -41,8 +28,8 @@ func (c *Client) GetADUser(objectID string) (*ADUser, error) {
 			SQLAdminRights        int    `json:"sqlAdminRights"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get ad user response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &response); err != nil {
+		return nil, err
 	}
 
 	user := response.Data.Props
@@ -63,38 +50,11 @@ func (c *Client) GetADUser(objectID string) (*ADUser, error) {
 
 // GetADUserByName fetches a single AD user by their name.
 func (c *Client) GetADUserByName(userName string) (*ADUser, error) {
-	// First, try searching with the provided username directly
-	searchResponse, err := c.Search(userName, "User", 0)
+	objectID, err := c.resolveObjectID("User", userName)
 	if err != nil {
 		return nil, err
 	}
-
-	// If the initial search yields no results and the username contains an "@",
-	// try searching for the part before the "@" as a fallback.
-	if len(searchResponse.Data) == 0 && strings.Contains(userName, "@") {
-		samAccountName := strings.Split(userName, "@")[0]
-		searchResponse, err = c.Search(samAccountName, "User", 0)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// Now, process the results from the successful search
-	for _, result := range searchResponse.Data {
-		// Use EqualFold for case-insensitive comparison on the result's name
-		if strings.EqualFold(result.Name, userName) || (strings.Contains(userName, "@") && strings.EqualFold(result.Name, strings.Split(userName, "@")[0])) {
-			return c.GetADUser(result.ObjectID)
-		}
-	}
-
-	// If we still haven't found a match, try a direct hit on the first result if there's only one.
-	// This handles cases where the name in BH is slightly different (e.g. UPN vs SAM)
-	if len(searchResponse.Data) == 1 {
-		return c.GetADUser(searchResponse.Data[0].ObjectID)
-	}
-
-
-	return nil, fmt.Errorf("user not found: %s", userName)
+	return c.GetADUser(objectID)
 }
 
 // GetADUserAdminRights fetches the admin rights for a given AD user.
@@ -106,19 +66,8 @@ func (c *Client) GetADUserAdminRights(objectID string, limit int) (EntityAdminsR
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserSessions fetches the sessions for a given AD user.
@@ -130,19 +79,8 @@ func (c *Client) GetADUserSessions(objectID string, limit int) (SessionsResponse
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserRDPRights fetches the RDP rights for a given AD user.
@@ -154,19 +92,8 @@ func (c *Client) GetADUserRDPRights(objectID string, limit int) (PrivilegesRespo
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserDCOMRights fetches the DCOM rights for a given AD user.
@@ -178,19 +105,8 @@ func (c *Client) GetADUserDCOMRights(objectID string, limit int) (PrivilegesResp
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserPSRemoteRights fetches the PSRemote rights for a given AD user.
@@ -202,19 +118,8 @@ func (c *Client) GetADUserPSRemoteRights(objectID string, limit int) (Privileges
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserSQLAdminRights fetches the SQL admin rights for a given AD user.
@@ -226,19 +131,8 @@ func (c *Client) GetADUserSQLAdminRights(objectID string, limit int) (Privileges
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserConstrainedDelegationRights fetches the constrained delegation rights for a given AD user.
@@ -250,19 +144,8 @@ func (c *Client) GetADUserConstrainedDelegationRights(objectID string, limit int
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserGroupMembership fetches the group membership for a given AD user.
@@ -274,19 +157,8 @@ func (c *Client) GetADUserGroupMembership(objectID string, limit int) (GroupMemb
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserControllers fetches the controllers of a given AD user.
@@ -298,19 +170,8 @@ func (c *Client) GetADUserControllers(objectID string, limit int) (ControllersRe
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetADUserControllables fetches the controllables of a given AD user.
@@ -322,51 +183,57 @@ func (c *Client) GetADUserControllables(objectID string, limit int) (Controllabl
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// ObjectControl splits one side of a user's object control the way the BloodHound UI
+// does: Direct holds the objects linked to the user by an ACL edge, GroupDelegated the
+// objects linked through the groups the user belongs to, directly or not.
+type ObjectControl struct {
+	Direct         []GraphNodeProperties
+	GroupDelegated []GraphNodeProperties
+}
+
+// GetADUserOutboundObjectControl fetches the objects a given AD user controls, split into
+// first-degree and group-delegated control. The API has no endpoint for the split, so it
+// is read with Cypher over the traversable ACL edges.
+func (c *Client) GetADUserOutboundObjectControl(ctx context.Context, objectID string) (*ObjectControl, error) {
+	return c.userObjectControl(ctx, objectID,
+		`MATCH (u:User)-[%s]->(n) WHERE u.objectid = %s RETURN n`,
+		`MATCH (u:User)-[:MemberOf*1..]->(:Group)-[%s]->(n) WHERE u.objectid = %s RETURN n`)
+}
+
+// GetADUserInboundObjectControl fetches the principals that control a given AD user, split
+// into explicit controllers and the members of controlling groups.
+func (c *Client) GetADUserInboundObjectControl(ctx context.Context, objectID string) (*ObjectControl, error) {
+	return c.userObjectControl(ctx, objectID,
+		`MATCH (n)-[%s]->(u:User) WHERE u.objectid = %s RETURN n`,
+		`MATCH (n)-[:MemberOf*1..]->(:Group)-[%s]->(u:User) WHERE u.objectid = %s RETURN n`)
+}
+
+func (c *Client) userObjectControl(ctx context.Context, objectID, directQuery, delegatedQuery string) (*ObjectControl, error) {
+	kinds := EdgeKindsWhere(func(info EdgeKindInfo) bool { return info.ACL && info.Traversable })
+	pattern := OnlyEdges(kinds...).cypherPattern()
+
+	direct, err := c.relatedNodes(ctx, fmt.Sprintf(directQuery, pattern, cypherString(objectID)), objectID)
 	if err != nil {
-		return rawResponse, err
+		return nil, err
 	}
-	resp, err := c.do(req, nil)
+	delegated, err := c.relatedNodes(ctx, fmt.Sprintf(delegatedQuery, pattern, cypherString(objectID)), objectID)
 	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
+		return nil, err
 	}
-	return rawResponse, nil
+	return &ObjectControl{Direct: direct, GroupDelegated: delegated}, nil
 }
 
 // ResolveUserIdentity takes a user identity (name or SID) and returns the SID.
-// If a name is provided, it will be resolved via the search API.
+// If a name is provided, it will be resolved with ResolveName, so it may be given as
+// NAME@DOMAIN, DOMAIN\sam, a SAM account name or a UPN.
 func (c *Client) ResolveUserIdentity(identity string) (string, error) {
 	// If the identity looks like a SID, return it directly.
 	if strings.HasPrefix(strings.ToUpper(identity), "S-1-5-") {
 		return identity, nil
 	}
-
-	// If not a SID, assume it's a name and search for it.
-	searchResponse, err := c.Search(identity, "User", 0)
-	if err != nil {
-		return "", fmt.Errorf("search failed for user '%s': %w", identity, err)
-	}
-
-	if len(searchResponse.Data) == 0 {
-		return "", fmt.Errorf("no user found matching name '%s'", identity)
-	}
-
-	// Prefer an exact (case-insensitive) match if there are multiple results.
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, identity) {
-			return result.ObjectID, nil
-		}
-	}
-
-	// If no exact match was found and there are multiple results, it's ambiguous.
-	if len(searchResponse.Data) > 1 {
-		return "", fmt.Errorf("multiple users found matching name '%s'. Please be more specific or use the Object ID (SID)", identity)
-	}
-
-	// Only one result, so we'll use it.
-	return searchResponse.Data[0].ObjectID, nil
-}
\ No newline at end of file
+	return c.resolveObjectID("User", identity)
+}
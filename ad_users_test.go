@@ -0,0 +1,61 @@
+package bloodhound
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestADUserObjectControl(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", func(w http.ResponseWriter, r *http.Request) {
+		var query CypherQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			t.Errorf("failed to decode cypher request: %v", err)
+		}
+		queries = append(queries, query.Query)
+		switch {
+		case strings.Contains(query.Query, "(u:User)-[:MemberOf*1..]"):
+			writeTestJSON(w, http.StatusOK, `{"data": {"nodes": {
+				"1": {"label": "ALICE@CORP.LOCAL", "kind": "User", "objectId": "S-1-5-21-1-1104"},
+				"2": {"label": "SRV01.CORP.LOCAL", "kind": "Computer", "objectId": "S-1-5-21-1-2001"}
+			}, "edges": []}}`)
+		case strings.Contains(query.Query, "(u:User)-["):
+			writeTestJSON(w, http.StatusOK, `{"data": {"nodes": {
+				"1": {"label": "ALICE@CORP.LOCAL", "kind": "User", "objectId": "S-1-5-21-1-1104"},
+				"3": {"label": "BOB@CORP.LOCAL", "kind": "User", "objectId": "S-1-5-21-1-1105"},
+				"4": {"label": "ADMINS@CORP.LOCAL", "kind": "Group", "objectId": "S-1-5-21-1-1200"}
+			}, "edges": []}}`)
+		default:
+			writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+		}
+	})
+	client := newTestClient(t, mux)
+
+	outbound, err := client.GetADUserOutboundObjectControl(context.Background(), "S-1-5-21-1-1104")
+	if err != nil {
+		t.Fatalf("GetADUserOutboundObjectControl returned an error: %v", err)
+	}
+	if len(outbound.Direct) != 2 || outbound.Direct[0].Name != "ADMINS@CORP.LOCAL" || outbound.Direct[1].Name != "BOB@CORP.LOCAL" {
+		t.Errorf("unexpected first-degree control: %+v", outbound.Direct)
+	}
+	if len(outbound.GroupDelegated) != 1 || outbound.GroupDelegated[0].Name != "SRV01.CORP.LOCAL" {
+		t.Errorf("unexpected group-delegated control: %+v", outbound.GroupDelegated)
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, "|GenericAll|") || strings.Contains(query, "GetChanges") {
+			t.Errorf("expected only traversable ACL edges in %q", query)
+		}
+	}
+
+	inbound, err := client.GetADUserInboundObjectControl(context.Background(), "S-1-5-21-1-1104")
+	if err != nil {
+		t.Fatalf("GetADUserInboundObjectControl returned an error: %v", err)
+	}
+	if len(inbound.Direct) != 0 || len(inbound.GroupDelegated) != 0 {
+		t.Errorf("expected no controllers when the queries match nothing, got %+v", inbound)
+	}
+}
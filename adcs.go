@@ -0,0 +1,253 @@
+package bloodhound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ADCSKinds lists the node kinds that make up Active Directory Certificate Services.
+var ADCSKinds = []string{"AIACA", "RootCA", "EnterpriseCA", "NTAuthStore", "CertTemplate", "IssuancePolicy"}
+
+// getADCSEntity fetches the properties of an ADCS node into props and returns its controller count.
+func (c *Client) getADCSEntity(endpoint, objectID string, props interface{}) (int, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/", endpoint, objectID)
+	var response struct {
+		Data struct {
+			Props       json.RawMessage `json:"props"`
+			Controllers int             `json:"controllers"`
+		} `json:"data"`
+	}
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(response.Data.Props, props); err != nil {
+		return 0, fmt.Errorf("failed to decode %s properties: %w", endpoint, err)
+	}
+	return response.Data.Controllers, nil
+}
+
+// getADCSControllers fetches the controllers of an ADCS node.
+func (c *Client) getADCSControllers(endpoint, objectID string, limit int) (ControllersResponse, error) {
+	var rawResponse ControllersResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/", endpoint, objectID, "/controllers")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
+	}
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetAIACA fetches a single AIACA by its Object ID.
+func (c *Client) GetAIACA(objectID string) (*AIACA, error) {
+	var ca AIACA
+	controllers, err := c.getADCSEntity("aiacas", objectID, &ca)
+	if err != nil {
+		return nil, err
+	}
+	ca.ObjectType = "AIACA"
+	ca.Controllers = controllers
+	return &ca, nil
+}
+
+// GetAIACAByName fetches a single AIACA by its name.
+func (c *Client) GetAIACAByName(name string) (*AIACA, error) {
+	objectID, err := c.resolveObjectID("AIACA", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAIACA(objectID)
+}
+
+// GetAIACAControllers fetches the controllers of a given AIACA.
+func (c *Client) GetAIACAControllers(objectID string, limit int) (ControllersResponse, error) {
+	return c.getADCSControllers("aiacas", objectID, limit)
+}
+
+// GetRootCA fetches a single root CA by its Object ID.
+func (c *Client) GetRootCA(objectID string) (*RootCA, error) {
+	var ca RootCA
+	controllers, err := c.getADCSEntity("rootcas", objectID, &ca)
+	if err != nil {
+		return nil, err
+	}
+	ca.ObjectType = "RootCA"
+	ca.Controllers = controllers
+	return &ca, nil
+}
+
+// GetRootCAByName fetches a single root CA by its name.
+func (c *Client) GetRootCAByName(name string) (*RootCA, error) {
+	objectID, err := c.resolveObjectID("RootCA", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetRootCA(objectID)
+}
+
+// GetRootCAControllers fetches the controllers of a given root CA.
+func (c *Client) GetRootCAControllers(objectID string, limit int) (ControllersResponse, error) {
+	return c.getADCSControllers("rootcas", objectID, limit)
+}
+
+// GetEnterpriseCA fetches a single enterprise CA by its Object ID.
+func (c *Client) GetEnterpriseCA(objectID string) (*EnterpriseCA, error) {
+	var ca EnterpriseCA
+	controllers, err := c.getADCSEntity("enterprisecas", objectID, &ca)
+	if err != nil {
+		return nil, err
+	}
+	ca.ObjectType = "EnterpriseCA"
+	ca.Controllers = controllers
+	return &ca, nil
+}
+
+// GetEnterpriseCAByName fetches a single enterprise CA by its name.
+func (c *Client) GetEnterpriseCAByName(name string) (*EnterpriseCA, error) {
+	objectID, err := c.resolveObjectID("EnterpriseCA", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetEnterpriseCA(objectID)
+}
+
+// GetEnterpriseCAControllers fetches the controllers of a given enterprise CA.
+func (c *Client) GetEnterpriseCAControllers(objectID string, limit int) (ControllersResponse, error) {
+	return c.getADCSControllers("enterprisecas", objectID, limit)
+}
+
+// GetNTAuthStore fetches a single NTAuth store by its Object ID.
+func (c *Client) GetNTAuthStore(objectID string) (*NTAuthStore, error) {
+	var store NTAuthStore
+	controllers, err := c.getADCSEntity("ntauthstores", objectID, &store)
+	if err != nil {
+		return nil, err
+	}
+	store.ObjectType = "NTAuthStore"
+	store.Controllers = controllers
+	return &store, nil
+}
+
+// GetNTAuthStoreByName fetches a single NTAuth store by its name.
+func (c *Client) GetNTAuthStoreByName(name string) (*NTAuthStore, error) {
+	objectID, err := c.resolveObjectID("NTAuthStore", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetNTAuthStore(objectID)
+}
+
+// GetNTAuthStoreControllers fetches the controllers of a given NTAuth store.
+func (c *Client) GetNTAuthStoreControllers(objectID string, limit int) (ControllersResponse, error) {
+	return c.getADCSControllers("ntauthstores", objectID, limit)
+}
+
+// GetCertTemplate fetches a single certificate template by its Object ID.
+func (c *Client) GetCertTemplate(objectID string) (*CertTemplate, error) {
+	var template CertTemplate
+	controllers, err := c.getADCSEntity("certtemplates", objectID, &template)
+	if err != nil {
+		return nil, err
+	}
+	template.ObjectType = "CertTemplate"
+	template.Controllers = controllers
+	return &template, nil
+}
+
+// GetCertTemplateByName fetches a single certificate template by its name.
+func (c *Client) GetCertTemplateByName(name string) (*CertTemplate, error) {
+	objectID, err := c.resolveObjectID("CertTemplate", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCertTemplate(objectID)
+}
+
+// GetCertTemplateControllers fetches the controllers of a given certificate template.
+func (c *Client) GetCertTemplateControllers(objectID string, limit int) (ControllersResponse, error) {
+	return c.getADCSControllers("certtemplates", objectID, limit)
+}
+
+// GetIssuancePolicy fetches a single issuance policy by its Object ID.
+func (c *Client) GetIssuancePolicy(objectID string) (*IssuancePolicy, error) {
+	var policy IssuancePolicy
+	controllers, err := c.getADCSEntity("issuancepolicies", objectID, &policy)
+	if err != nil {
+		return nil, err
+	}
+	policy.ObjectType = "IssuancePolicy"
+	policy.Controllers = controllers
+	return &policy, nil
+}
+
+// GetIssuancePolicyByName fetches a single issuance policy by its name.
+func (c *Client) GetIssuancePolicyByName(name string) (*IssuancePolicy, error) {
+	objectID, err := c.resolveObjectID("IssuancePolicy", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetIssuancePolicy(objectID)
+}
+
+// GetIssuancePolicyControllers fetches the controllers of a given issuance policy.
+func (c *Client) GetIssuancePolicyControllers(objectID string, limit int) (ControllersResponse, error) {
+	return c.getADCSControllers("issuancepolicies", objectID, limit)
+}
+
+// GetEnterpriseCAPublishedTemplates fetches the certificate templates published to a given enterprise CA.
+func (c *Client) GetEnterpriseCAPublishedTemplates(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH p=(:CertTemplate)-[:PublishedTo]->(n:EnterpriseCA) WHERE n.objectid = %s RETURN p`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// GetCertTemplatePublishingCAs fetches the enterprise CAs a given certificate template is published to.
+func (c *Client) GetCertTemplatePublishingCAs(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH p=(n:CertTemplate)-[:PublishedTo]->(:EnterpriseCA) WHERE n.objectid = %s RETURN p`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// GetNTAuthStoreTrustedCAs fetches the enterprise CAs trusted for NT authentication by a given NTAuth store.
+func (c *Client) GetNTAuthStoreTrustedCAs(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH p=(:EnterpriseCA)-[:TrustedForNTAuth]->(n:NTAuthStore) WHERE n.objectid = %s RETURN p`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// GetEnterpriseCAIssuerChain fetches the CAs (intermediate and root) that signed a given enterprise CA's certificate.
+func (c *Client) GetEnterpriseCAIssuerChain(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH p=(n:EnterpriseCA)-[:IssuedSignedBy*1..]->() WHERE n.objectid = %s RETURN p`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// GetRootCAIssuedCAs fetches the enterprise CAs whose certificates chain up to a given root CA.
+// Only the enterprise CAs are returned, not the intermediate CAs of their chains.
+func (c *Client) GetRootCAIssuedCAs(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH (n:EnterpriseCA)-[:IssuedSignedBy*1..]->(r:RootCA) WHERE r.objectid = %s RETURN n`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// GetCertTemplateIssuancePolicies fetches the issuance policies that extend a given certificate template.
+func (c *Client) GetCertTemplateIssuancePolicies(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH p=(n:CertTemplate)-[:ExtendedByPolicy]->(:IssuancePolicy) WHERE n.objectid = %s RETURN p`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// GetIssuancePolicyLinkedGroups fetches the groups linked to a given issuance policy through an OID group link.
+func (c *Client) GetIssuancePolicyLinkedGroups(objectID string) ([]GraphNodeProperties, error) {
+	query := fmt.Sprintf(`MATCH p=(n:IssuancePolicy)-[:OIDGroupLink]->(:Group) WHERE n.objectid = %s RETURN p`, cypherString(strings.ToUpper(objectID)))
+	return c.relatedNodes(context.Background(), query, objectID)
+}
+
+// SearchADCS searches every ADCS node kind for the given term with a single request.
+// The limit applies to the combined results.
+func (c *Client) SearchADCS(searchTerm string, limit int) ([]SearchResult, error) {
+	searchResponse, err := c.SearchWithOptions(searchTerm, SearchOptions{Kinds: ADCSKinds, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return searchResponse.Data, nil
+}
@@ -0,0 +1,93 @@
+package bloodhound
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestADCSRelatedNodes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", cypherHandler(t, map[string]string{
+		`"ECA-1"`: `{
+			"nodes": {
+				"1": {"label": "CORP-CA@CORP.LOCAL", "kind": "EnterpriseCA", "objectId": "ECA-1"},
+				"2": {"label": "WEBSERVER@CORP.LOCAL", "kind": "CertTemplate", "objectId": "TPL-2"},
+				"3": {"label": "USER@CORP.LOCAL", "kind": "CertTemplate", "objectId": "TPL-1"}
+			},
+			"edges": [
+				{"source": "2", "target": "1", "label": "PublishedTo", "kind": "PublishedTo"},
+				{"source": "3", "target": "1", "label": "PublishedTo", "kind": "PublishedTo"}
+			]
+		}`,
+		// Only the enterprise CA is returned, not the intermediate CA of its chain.
+		`(n:EnterpriseCA)-[:IssuedSignedBy*1..]->(r:RootCA) WHERE r.objectid = "ROOT-1" RETURN n`: `{
+			"nodes": {"1": {"label": "CORP-CA@CORP.LOCAL", "kind": "EnterpriseCA", "objectId": "ECA-1"}},
+			"edges": []
+		}`,
+	}))
+	client := newTestClient(t, mux)
+
+	issued, err := client.GetRootCAIssuedCAs("root-1")
+	if err != nil {
+		t.Fatalf("GetRootCAIssuedCAs returned an error: %v", err)
+	}
+	if len(issued) != 1 || issued[0].ObjectID != "ECA-1" {
+		t.Errorf("expected only the enterprise CA, got %+v", issued)
+	}
+
+	templates, err := client.GetEnterpriseCAPublishedTemplates("ECA-1")
+	if err != nil {
+		t.Fatalf("GetEnterpriseCAPublishedTemplates returned an error: %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "USER@CORP.LOCAL" || templates[1].Name != "WEBSERVER@CORP.LOCAL" {
+		t.Errorf("expected the two templates sorted by name without the CA itself, got %+v", templates)
+	}
+
+	empty := map[string]func(string) ([]GraphNodeProperties, error){
+		"GetEnterpriseCAPublishedTemplates": client.GetEnterpriseCAPublishedTemplates,
+		"GetCertTemplatePublishingCAs":      client.GetCertTemplatePublishingCAs,
+		"GetNTAuthStoreTrustedCAs":          client.GetNTAuthStoreTrustedCAs,
+		"GetEnterpriseCAIssuerChain":        client.GetEnterpriseCAIssuerChain,
+		"GetRootCAIssuedCAs":                client.GetRootCAIssuedCAs,
+		"GetCertTemplateIssuancePolicies":   client.GetCertTemplateIssuancePolicies,
+		"GetIssuancePolicyLinkedGroups":     client.GetIssuancePolicyLinkedGroups,
+	}
+	for name, get := range empty {
+		nodes, err := get("UNRELATED")
+		if err != nil {
+			t.Errorf("%s without relationships returned an error: %v", name, err)
+			continue
+		}
+		if nodes == nil || len(nodes) != 0 {
+			t.Errorf("%s without relationships = %#v, want an empty slice", name, nodes)
+		}
+	}
+}
+
+func TestSearchADCS(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/search", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query()["type"]; !reflect.DeepEqual(got, ADCSKinds) {
+			t.Errorf("searched types %v, want %v", got, ADCSKinds)
+		}
+		if got := r.URL.Query().Get("limit"); got != "10" {
+			t.Errorf("limit = %q, want 10", got)
+		}
+		writeTestJSON(w, http.StatusOK, `{"data": [
+			{"objectid": "ECA-1", "name": "CORP-CA@CORP.LOCAL", "type": "EnterpriseCA"},
+			{"objectid": "TPL-1", "name": "CORP-USER@CORP.LOCAL", "type": "CertTemplate"}
+		]}`)
+	})
+	client := newTestClient(t, mux)
+
+	results, err := client.SearchADCS("corp", 10)
+	if err != nil {
+		t.Fatalf("SearchADCS returned an error: %v", err)
+	}
+	if requests != 1 || len(results) != 2 || results[1].ObjectID != "TPL-1" {
+		t.Errorf("got %d results after %d requests, want 2 after 1: %+v", len(results), requests, results)
+	}
+}
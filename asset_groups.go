@@ -1,33 +1,19 @@
 package bloodhound
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // getOwnedAssetGroupID fetches all asset groups and returns the ID of the "Owned" group.
 func (c *Client) getOwnedAssetGroupID() (int, error) {
 	assetGroupsURL := c.baseURL.JoinPath("/api/v2/asset-groups")
-	req, err := c.newAuthenticatedRequest(http.MethodGet, assetGroupsURL.String(), nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create get asset groups request: %w", err)
-	}
-
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to execute get asset groups request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("get asset groups failed with status code: %d", resp.StatusCode)
-	}
-
 	var assetGroupsResponse AssetGroupsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetGroupsResponse); err != nil {
-		return 0, fmt.Errorf("failed to decode asset groups response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, assetGroupsURL, nil, &assetGroupsResponse); err != nil {
+		return 0, err
 	}
 
 	for _, group := range assetGroupsResponse.Data.AssetGroups {
@@ -47,53 +33,77 @@ func (c *Client) UpdateOwnedStatus(updates []OwnershipUpdate) error {
 	}
 
 	updateURL := c.baseURL.JoinPath("/api/v2/asset-groups/", fmt.Sprintf("%d", ownedGroupID), "/selectors")
+	return c.doJSON(context.Background(), http.MethodPut, updateURL, updates, nil)
+}
 
-	payload, err := json.Marshal(updates)
-	if err != nil {
-		return fmt.Errorf("failed to marshal ownership update payload: %w", err)
-	}
+// UpdateAssetGroupMembers adds or removes objects from an asset group.
+func (c *Client) UpdateAssetGroupMembers(assetGroupID int, updates []AssetGroupSelectorUpdate) error {
+	updateURL := c.baseURL.JoinPath("/api/v2/asset-groups/", fmt.Sprintf("%d", assetGroupID), "/selectors")
+	return c.doJSON(context.Background(), http.MethodPut, updateURL, updates, nil)
+}
 
-	req, err := c.newAuthenticatedRequest(http.MethodPut, updateURL.String(), bytes.NewBuffer(payload))
-	if err != nil {
-		return fmt.Errorf("failed to create ownership update request: %w", err)
+// ListAssetGroups fetches all asset groups.
+func (c *Client) ListAssetGroups() ([]AssetGroup, error) {
+	return c.listAssetGroups(context.Background())
+}
+
+func (c *Client) listAssetGroups(ctx context.Context) ([]AssetGroup, error) {
+	var assetGroupsResponse AssetGroupsResponse
+	assetGroupsURL := c.baseURL.JoinPath("/api/v2/asset-groups")
+	if err := c.doJSON(ctx, http.MethodGet, assetGroupsURL, nil, &assetGroupsResponse); err != nil {
+		return nil, err
 	}
+	return assetGroupsResponse.Data.AssetGroups, nil
+}
 
-	resp, err := c.do(req, nil)
+// GetAssetGroupByTag returns the asset group carrying the given tag (e.g. "admin_tier_0" or "owned").
+func (c *Client) GetAssetGroupByTag(tag string) (*AssetGroup, error) {
+	return c.getAssetGroupByTag(context.Background(), tag)
+}
+
+func (c *Client) getAssetGroupByTag(ctx context.Context, tag string) (*AssetGroup, error) {
+	groups, err := c.listAssetGroups(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to execute ownership update request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("ownership update failed with status code: %d", resp.StatusCode)
+	for _, group := range groups {
+		if group.Tag == tag {
+			return &group, nil
+		}
 	}
 
-	return nil
+	return nil, fmt.Errorf("could not find an asset group with tag '%s'", tag)
 }
 
-// UpdateAssetGroupMembers adds or removes objects from an asset group.
-func (c *Client) UpdateAssetGroupMembers(assetGroupID int, updates []AssetGroupSelectorUpdate) error {
-	updateURL := c.baseURL.JoinPath("/api/v2/asset-groups/", fmt.Sprintf("%d", assetGroupID), "/selectors")
-
-	payload, err := json.Marshal(updates)
-	if err != nil {
-		return fmt.Errorf("failed to marshal asset group update payload: %w", err)
-	}
+// GetAssetGroupMembers fetches a page of the members of an asset group.
+func (c *Client) GetAssetGroupMembers(assetGroupID int, skip, limit int) (AssetGroupMembersResponse, error) {
+	return c.getAssetGroupMembers(context.Background(), assetGroupID, skip, limit)
+}
 
-	req, err := c.newAuthenticatedRequest("PUT", updateURL.String(), bytes.NewBuffer(payload))
-	if err != nil {
-		return fmt.Errorf("failed to create asset group update request: %w", err)
+func (c *Client) getAssetGroupMembers(ctx context.Context, assetGroupID int, skip, limit int) (AssetGroupMembersResponse, error) {
+	var rawResponse AssetGroupMembersResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/asset-groups/", strconv.Itoa(assetGroupID), "/members")
+	params := url.Values{}
+	if skip > 0 {
+		params.Add("skip", strconv.Itoa(skip))
 	}
-
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return fmt.Errorf("failed to execute asset group update request: %w", err)
+	if limit > 0 {
+		params.Add("limit", strconv.Itoa(limit))
 	}
-	defer resp.Body.Close()
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("asset group update failed with status code: %d", resp.StatusCode)
-	}
+// ListAllAssetGroupMembers pages through every member of an asset group.
+func (c *Client) ListAllAssetGroupMembers(assetGroupID int) ([]AssetGroupMember, error) {
+	return c.listAllAssetGroupMembers(context.Background(), assetGroupID)
+}
 
-	return nil
+func (c *Client) listAllAssetGroupMembers(ctx context.Context, assetGroupID int) ([]AssetGroupMember, error) {
+	return listAll(listPageSize, func(skip, limit int) ([]AssetGroupMember, int, error) {
+		page, err := c.getAssetGroupMembers(ctx, assetGroupID, skip, limit)
+		return page.Data.Members, page.Count, err
+	})
 }
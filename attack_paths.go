@@ -1,29 +1,67 @@
 package bloodhound
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
+// FindingSeverity is the severity BloodHound assigns to an attack path finding.
+type FindingSeverity string
+
+const (
+	SeverityCritical FindingSeverity = "critical"
+	SeverityHigh     FindingSeverity = "high"
+	SeverityModerate FindingSeverity = "moderate"
+	SeverityLow      FindingSeverity = "low"
+	SeverityNone     FindingSeverity = ""
+)
+
+// SeverityForRisk maps a composite risk or exposure percentage (0-100) to a severity,
+// using the same thresholds as the BloodHound UI.
+func SeverityForRisk(percentage float64) FindingSeverity {
+	switch {
+	case percentage >= 95:
+		return SeverityCritical
+	case percentage >= 80:
+		return SeverityHigh
+	case percentage >= 40:
+		return SeverityModerate
+	case percentage > 0:
+		return SeverityLow
+	default:
+		return SeverityNone
+	}
+}
+
+// FindingTypeSummary summarizes a single finding type within a domain.
+type FindingTypeSummary struct {
+	Finding string
+	// FindingCount is the number of findings of the type. A principal can appear in
+	// several relationship findings, so it is not a count of distinct principals.
+	FindingCount  int
+	ImpactedCount int
+	CompositeRisk float64
+	Severity      FindingSeverity
+}
+
 // ListAttackPaths fetches the list of available attack paths.
 func (c *Client) ListAttackPaths() ([]AttackPath, error) {
 	url := c.baseURL.JoinPath("/api/v2/attack-paths")
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
 	var rawResponse AttackPathsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &rawResponse); err != nil {
 		return nil, err
 	}
 	var finalResponse []AttackPath
+	if len(rawResponse.Data) == 0 || string(rawResponse.Data) == "null" {
+		return finalResponse, nil
+	}
 	if err := json.Unmarshal(rawResponse.Data, &finalResponse); err != nil {
-		return []AttackPath{}, nil
+		return nil, fmt.Errorf("failed to decode attack paths: %w", err)
 	}
 	return finalResponse, nil
 }
@@ -31,22 +69,157 @@ func (c *Client) ListAttackPaths() ([]AttackPath, error) {
 // ListAttackPathFindings fetches the list of attack path findings.
 func (c *Client) ListAttackPathFindings() ([]AttackPathFinding, error) {
 	url := c.baseURL.JoinPath("/api/v2/findings")
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
+	var rawResponse AttackPathFindingsResponse
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &rawResponse); err != nil {
 		return nil, err
 	}
-	resp, err := c.do(req, nil)
-	if err != nil {
+	return rawResponse.Data, nil
+}
+
+// ListAttackPathTypes fetches every attack path finding type the server knows about.
+func (c *Client) ListAttackPathTypes() ([]string, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/attack-path-types")
+	return c.getFindingTypes(apiUrl)
+}
+
+// ListDomainFindingTypes fetches the finding types that have findings in a given domain.
+func (c *Client) ListDomainFindingTypes(domainID string) ([]string, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/domains/", domainID, "/available-types")
+	return c.getFindingTypes(apiUrl)
+}
+
+func (c *Client) getFindingTypes(apiUrl *url.URL) ([]string, error) {
+	var response struct {
+		Data []string `json:"data"`
+	}
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return response.Data, nil
+}
+
+// ListDomainFindingDetails fetches a page of findings of the given type in a domain.
+func (c *Client) ListDomainFindingDetails(domainID, findingType string, skip, limit int) (AttackPathFindingsResponse, error) {
 	var rawResponse AttackPathFindingsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
+	apiUrl := c.baseURL.JoinPath("/api/v2/domains/", domainID, "/details")
+	params := url.Values{}
+	params.Add("finding", findingType)
+	if skip > 0 {
+		params.Add("skip", strconv.Itoa(skip))
+	}
+	if limit > 0 {
+		params.Add("limit", strconv.Itoa(limit))
+	}
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetDomainFindingSparkline fetches the trend of a finding type in a domain between two points in time.
+// A zero from or to leaves that end of the range open.
+func (c *Client) GetDomainFindingSparkline(domainID, findingType string, from, to time.Time) ([]FindingSparklinePoint, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/domains/", domainID, "/sparkline")
+	params := url.Values{}
+	params.Add("finding", findingType)
+	if !from.IsZero() {
+		params.Add("from", from.UTC().Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		params.Add("to", to.UTC().Format(time.RFC3339))
+	}
+	apiUrl.RawQuery = params.Encode()
+
+	var response struct {
+		Data []FindingSparklinePoint `json:"data"`
+	}
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
 		return nil, err
 	}
-	var finalResponse []AttackPathFinding
-	if err := json.Unmarshal(rawResponse.Data, &finalResponse); err != nil {
-		return []AttackPathFinding{}, nil
+	return response.Data, nil
+}
+
+// SummarizeDomainFindings returns finding and impacted counts, composite risk and
+// severity for every finding type present in a domain. The counts come from the latest
+// sparkline point; the finding details are only read for types without one.
+func (c *Client) SummarizeDomainFindings(domainID string) ([]FindingTypeSummary, error) {
+	findingTypes, err := c.ListDomainFindingTypes(domainID)
+	if err != nil {
+		return nil, err
 	}
-	return finalResponse, nil
+
+	summaries := make([]FindingTypeSummary, 0, len(findingTypes))
+	for _, findingType := range findingTypes {
+		summary := FindingTypeSummary{Finding: findingType}
+		sparkline, err := c.GetDomainFindingSparkline(domainID, findingType, time.Time{}, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		if len(sparkline) > 0 {
+			latest := sparkline[0]
+			for _, point := range sparkline[1:] {
+				if point.CreatedAt.After(latest.CreatedAt) {
+					latest = point
+				}
+			}
+			summary.FindingCount = latest.FindingCount
+			summary.CompositeRisk = latest.CompositeRisk
+			summary.ImpactedCount = latest.ImpactedAssetCount
+		} else {
+			details, err := c.ListDomainFindingDetails(domainID, findingType, 0, 1)
+			if err != nil {
+				return nil, err
+			}
+			summary.FindingCount = details.Count
+		}
+		summary.Severity = SeverityForRisk(summary.CompositeRisk)
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// ListAllDomainFindingDetails pages through every finding of the given type in a domain.
+func (c *Client) ListAllDomainFindingDetails(domainID, findingType string) ([]AttackPathFinding, error) {
+	return listAll(listPageSize, func(skip, limit int) ([]AttackPathFinding, int, error) {
+		page, err := c.ListDomainFindingDetails(domainID, findingType, skip, limit)
+		return page.Data, page.Count, err
+	})
+}
+
+// AcceptFindingRisk accepts the risk of a finding until the given time.
+// A zero until accepts the risk indefinitely.
+func (c *Client) AcceptFindingRisk(findingID int64, findingType string, until time.Time) error {
+	request := RiskAcceptanceRequest{RiskType: findingType, Accepted: true}
+	if !until.IsZero() {
+		until = until.UTC()
+		request.AcceptUntil = &until
+	}
+	return c.updateFindingAcceptance(findingID, request)
+}
+
+// UnacceptFindingRisk revokes a previous risk acceptance on a finding.
+func (c *Client) UnacceptFindingRisk(findingID int64, findingType string) error {
+	return c.updateFindingAcceptance(findingID, RiskAcceptanceRequest{RiskType: findingType, Accepted: false})
+}
+
+func (c *Client) updateFindingAcceptance(findingID int64, request RiskAcceptanceRequest) error {
+	apiUrl := c.baseURL.JoinPath("/api/v2/attack-paths/", strconv.FormatInt(findingID, 10), "/acceptance")
+	return c.doJSON(context.Background(), http.MethodPut, apiUrl, request, nil)
+}
+
+// ListAcceptedFindings returns the findings of the given type in a domain whose risk is
+// currently accepted, including when the acceptance expires and who accepted it.
+func (c *Client) ListAcceptedFindings(domainID, findingType string) ([]AttackPathFinding, error) {
+	findings, err := c.ListAllDomainFindingDetails(domainID, findingType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var accepted []AttackPathFinding
+	for _, finding := range findings {
+		if finding.IsAccepted(now) {
+			accepted = append(accepted, finding)
+		}
+	}
+	return accepted, nil
 }
@@ -0,0 +1,229 @@
+package bloodhound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindingRiskAcceptance(t *testing.T) {
+	var requests []RiskAcceptanceRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/attack-paths/42/acceptance", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("acceptance request used %s", r.Method)
+		}
+		var request RiskAcceptanceRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode acceptance request: %v", err)
+		}
+		requests = append(requests, request)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux)
+
+	until := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	if err := client.AcceptFindingRisk(42, "T0MarkSensitive", until); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AcceptFindingRisk(42, "T0MarkSensitive", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UnacceptFindingRisk(42, "T0MarkSensitive"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("got %d acceptance requests, want 3", len(requests))
+	}
+	if r := requests[0]; !r.Accepted || r.RiskType != "T0MarkSensitive" || r.AcceptUntil == nil || !r.AcceptUntil.Equal(until) {
+		t.Errorf("unexpected timed acceptance: %+v", r)
+	}
+	if r := requests[1]; !r.Accepted || r.AcceptUntil != nil {
+		t.Errorf("unexpected indefinite acceptance: %+v", r)
+	}
+	if r := requests[2]; r.Accepted || r.AcceptUntil != nil {
+		t.Errorf("unexpected unacceptance: %+v", r)
+	}
+}
+
+func TestListAcceptedFindings(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/domains/S-1-5-21-1/details", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("finding"); got != "Kerberoasting" {
+			t.Errorf("finding = %q", got)
+		}
+		writeTestJSON(w, http.StatusOK, `{"count": 4, "data": [
+			{"id": 1, "Accepted": true},
+			{"id": 2, "Accepted": true, "AcceptedUntil": "`+future+`"},
+			{"id": 3, "Accepted": true, "AcceptedUntil": "`+past+`"},
+			{"id": 4}
+		]}`)
+	})
+	client := newTestClient(t, mux)
+
+	accepted, err := client.ListAcceptedFindings("S-1-5-21-1", "Kerberoasting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accepted) != 2 || accepted[0].ID != 1 || accepted[1].ID != 2 {
+		t.Fatalf("unexpected accepted findings: %+v", accepted)
+	}
+	if !accepted[0].IsAcceptedIndefinitely() || accepted[1].IsAcceptedIndefinitely() {
+		t.Error("only the finding without an expiry should be accepted indefinitely")
+	}
+}
+
+func TestSeverityForRisk(t *testing.T) {
+	tests := []struct {
+		risk float64
+		want FindingSeverity
+	}{
+		{100, SeverityCritical},
+		{95, SeverityCritical},
+		{94.9, SeverityHigh},
+		{80, SeverityHigh},
+		{79.9, SeverityModerate},
+		{40, SeverityModerate},
+		{39.9, SeverityLow},
+		{0.1, SeverityLow},
+		{0, SeverityNone},
+	}
+	for _, tt := range tests {
+		if got := SeverityForRisk(tt.risk); got != tt.want {
+			t.Errorf("SeverityForRisk(%v) = %q, want %q", tt.risk, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeDomainFindings(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/domains/S-1-5-21-1/available-types", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, `{"data": ["T0DCSync", "T0Kerberoast"]}`)
+	})
+	mux.HandleFunc("/api/v2/domains/S-1-5-21-1/details", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "1" {
+			t.Errorf("details were requested with limit %q", r.URL.Query().Get("limit"))
+		}
+		// Only the type without a sparkline falls back to the details.
+		if finding := r.URL.Query().Get("finding"); finding != "T0Kerberoast" {
+			t.Errorf("details were requested for %s", finding)
+		}
+		writeTestJSON(w, http.StatusOK, `{"count": 12, "data": []}`)
+	})
+	mux.HandleFunc("/api/v2/domains/S-1-5-21-1/sparkline", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("finding") == "T0Kerberoast" {
+			writeTestJSON(w, http.StatusOK, `{"data": []}`)
+			return
+		}
+		// The latest point wins regardless of order.
+		writeTestJSON(w, http.StatusOK, `{"data": [
+			{"CompositeRisk": 50, "FindingCount": 1, "ImpactedAssetCount": 10, "created_at": "2024-05-01T00:00:00Z"},
+			{"CompositeRisk": 96.5, "FindingCount": 3, "ImpactedAssetCount": 120, "created_at": "2024-05-03T00:00:00Z"},
+			{"CompositeRisk": 81, "FindingCount": 2, "ImpactedAssetCount": 90, "created_at": "2024-05-02T00:00:00Z"}
+		]}`)
+	})
+	client := newTestClient(t, mux)
+
+	summaries, err := client.SummarizeDomainFindings("S-1-5-21-1")
+	if err != nil {
+		t.Fatalf("SummarizeDomainFindings returned an error: %v", err)
+	}
+	want := []FindingTypeSummary{
+		{Finding: "T0DCSync", FindingCount: 3, ImpactedCount: 120, CompositeRisk: 96.5, Severity: SeverityCritical},
+		{Finding: "T0Kerberoast", FindingCount: 12, Severity: SeverityNone},
+	}
+	if !reflect.DeepEqual(summaries, want) {
+		t.Errorf("SummarizeDomainFindings = %+v, want %+v", summaries, want)
+	}
+
+	if _, err := client.SummarizeDomainFindings("S-1-5-21-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown domain, got %v", err)
+	}
+}
+
+// domainDetailsFixture has the shape of the /api/v2/domains/{id}/details responses of a
+// list finding and a relationship finding.
+const domainDetailsFixture = `{"count": 2, "limit": 10, "skip": 0, "data": [
+	{
+		"Principal": "S-1-5-21-1-1105",
+		"PrincipalKind": "User",
+		"Finding": "T0Kerberoast",
+		"DomainSID": "S-1-5-21-1",
+		"Props": {"name": "SVC_SQL@CORP.LOCAL", "hasspn": true},
+		"Accepted": true,
+		"AcceptedUntil": "2030-01-01T00:00:00Z",
+		"ImpactPercentage": 0.25,
+		"ImpactCount": 10,
+		"ExposurePercentage": 0.5,
+		"ExposureCount": 20,
+		"id": 7,
+		"created_at": "2024-05-01T10:00:00Z",
+		"updated_at": "2024-05-02T10:00:00Z",
+		"deleted_at": {"Time": "0001-01-01T00:00:00Z", "Valid": false}
+	},
+	{
+		"FromPrincipal": "S-1-5-21-1-513",
+		"ToPrincipal": "S-1-5-21-1-512",
+		"FromPrincipalProps": {"name": "DOMAIN USERS@CORP.LOCAL"},
+		"FromPrincipalKind": "Group",
+		"ToPrincipalProps": {"name": "DOMAIN ADMINS@CORP.LOCAL"},
+		"ToPrincipalKind": "Group",
+		"RelProps": {"isacl": true},
+		"Finding": "T0GenericAll",
+		"DomainSID": "S-1-5-21-1",
+		"PrincipalHash": "a1b2",
+		"AcceptedUntil": "0001-01-01T00:00:00Z",
+		"Severity": "critical",
+		"id": 8,
+		"created_at": "2024-05-01T10:00:00Z",
+		"updated_at": "2024-05-01T10:00:00Z",
+		"deleted_at": {"Time": "0001-01-01T00:00:00Z", "Valid": false}
+	}
+]}`
+
+func TestAttackPathFindingDecode(t *testing.T) {
+	var response AttackPathFindingsResponse
+	if err := json.Unmarshal([]byte(domainDetailsFixture), &response); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if response.Count != 2 || len(response.Data) != 2 {
+		t.Fatalf("got count %d with %d findings, want 2", response.Count, len(response.Data))
+	}
+
+	list := response.Data[0]
+	want := AttackPathFinding{
+		ID:                 7,
+		Finding:            "T0Kerberoast",
+		DomainSID:          "S-1-5-21-1",
+		Principal:          "S-1-5-21-1-1105",
+		PrincipalKind:      "User",
+		PrincipalProps:     map[string]interface{}{"name": "SVC_SQL@CORP.LOCAL", "hasspn": true},
+		ImpactPercentage:   0.25,
+		ImpactCount:        10,
+		ExposurePercentage: 0.5,
+		ExposureCount:      20,
+		Accepted:           true,
+		AcceptedUntil:      time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:          time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:          time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("list finding = %+v, want %+v", list, want)
+	}
+
+	relationship := response.Data[1]
+	if relationship.ID != 8 || relationship.Finding != "T0GenericAll" || relationship.DomainSID != "S-1-5-21-1" ||
+		relationship.FromPrincipal != "S-1-5-21-1-513" || relationship.FromPrincipalKind != "Group" ||
+		relationship.ToPrincipal != "S-1-5-21-1-512" || relationship.ToPrincipalKind != "Group" ||
+		relationship.ToPrincipalProps["name"] != "DOMAIN ADMINS@CORP.LOCAL" || relationship.RelProps["isacl"] != true ||
+		relationship.Severity != SeverityCritical || relationship.IsAccepted(time.Now()) {
+		t.Errorf("unexpected relationship finding: %+v", relationship)
+	}
+}
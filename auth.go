@@ -2,6 +2,7 @@ package bloodhound
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -60,19 +61,8 @@ func (c *Client) Login(username, password string) error {
 // Logout invalidates the current session token.
 func (c *Client) Logout() error {
 	logoutURL := c.baseURL.JoinPath("/api/v2/logout")
-	req, err := c.newAuthenticatedRequest(http.MethodPost, logoutURL.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create logout request: %w", err)
-	}
-
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return fmt.Errorf("failed to execute logout request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("logout failed with status code: %d", resp.StatusCode)
+	if err := c.doJSON(context.Background(), http.MethodPost, logoutURL, nil, nil); err != nil {
+		return err
 	}
 
 	c.setAuthToken("")
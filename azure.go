@@ -1,34 +1,23 @@
 package bloodhound
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
 // GetAzureEntity fetches a generic Azure entity by its Object ID.
 func (c *Client) GetAzureEntity(objectID string) (json.RawMessage, error) {
 	url := c.baseURL.JoinPath("/api/v2/azure/entities/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get azure entity failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data json.RawMessage `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get azure entity response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &response); err != nil {
+		return nil, err
 	}
 	return response.Data, nil
 }
@@ -36,27 +25,14 @@ func (c *Client) GetAzureEntity(objectID string) (json.RawMessage, error) {
 // GetAzureUser fetches a single Azure user by its Object ID.
 func (c *Client) GetAzureUser(objectID string) (*AzureUser, error) {
 	url := c.baseURL.JoinPath("/api/v2/azure/entities/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get azure user failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
 			Props AzureUser `json:"props"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get azure user response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &response); err != nil {
+		return nil, err
 	}
 	user := response.Data.Props
 	user.ObjectType = "AZUser"
@@ -65,36 +41,16 @@ func (c *Client) GetAzureUser(objectID string) (*AzureUser, error) {
 
 // GetAzureUserByName fetches a single Azure user by their User Principal Name.
 func (c *Client) GetAzureUserByName(userName string) (*AzureUser, error) {
-	searchResponse, err := c.Search(userName, "AZUser", 0)
+	objectID, err := c.resolveObjectID("AZUser", userName)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, userName) {
-			return c.GetAzureUser(result.ObjectID)
-		}
-	}
-
-	return nil, fmt.Errorf("azure user not found: %s", userName)
+	return c.GetAzureUser(objectID)
 }
 
 // GetAzureGroup fetches a single Azure group by its Object ID.
 func (c *Client) GetAzureGroup(objectID string) (*AzureGroup, error) {
 	url := c.baseURL.JoinPath("/api/v2/azure/entities/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get azure group failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -102,8 +58,8 @@ func (c *Client) GetAzureGroup(objectID string) (*AzureGroup, error) {
 			BaseEntity BaseAzureEntity `json:"base"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get azure group response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &response); err != nil {
+		return nil, err
 	}
 	group := response.Data.Props
 	group.BaseAzureEntity = response.Data.BaseEntity
@@ -113,36 +69,16 @@ func (c *Client) GetAzureGroup(objectID string) (*AzureGroup, error) {
 
 // GetAzureGroupByName fetches a single Azure group by its name.
 func (c *Client) GetAzureGroupByName(groupName string) (*AzureGroup, error) {
-	searchResponse, err := c.Search(groupName, "AZGroup", 0)
+	objectID, err := c.resolveObjectID("AZGroup", groupName)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, groupName) {
-			return c.GetAzureGroup(result.ObjectID)
-		}
-	}
-
-	return nil, fmt.Errorf("azure group not found: %s", groupName)
+	return c.GetAzureGroup(objectID)
 }
 
 // GetAzureVM fetches a single Azure VM by its Object ID.
 func (c *Client) GetAzureVM(objectID string) (*AzureVM, error) {
 	url := c.baseURL.JoinPath("/api/v2/azure/entities/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get azure vm failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -150,8 +86,8 @@ func (c *Client) GetAzureVM(objectID string) (*AzureVM, error) {
 			BaseEntity BaseAzureEntity `json:"base"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get azure vm response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &response); err != nil {
+		return nil, err
 	}
 	vm := response.Data.Props
 	vm.BaseAzureEntity = response.Data.BaseEntity
@@ -161,36 +97,16 @@ func (c *Client) GetAzureVM(objectID string) (*AzureVM, error) {
 
 // GetAzureVMByName fetches a single Azure VM by its name.
 func (c *Client) GetAzureVMByName(vmName string) (*AzureVM, error) {
-	searchResponse, err := c.Search(vmName, "AZVM", 0)
+	objectID, err := c.resolveObjectID("AZVM", vmName)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, vmName) {
-			return c.GetAzureVM(result.ObjectID)
-		}
-	}
-
-	return nil, fmt.Errorf("azure vm not found: %s", vmName)
+	return c.GetAzureVM(objectID)
 }
 
 // GetAzureTenant fetches a single Azure tenant by its Object ID.
 func (c *Client) GetAzureTenant(objectID string) (*AzureTenant, error) {
 	url := c.baseURL.JoinPath("/api/v2/azure-tenants/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get azure tenant failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -198,8 +114,8 @@ func (c *Client) GetAzureTenant(objectID string) (*AzureTenant, error) {
 			BaseEntity BaseAzureEntity `json:"base"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get azure tenant response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, url, nil, &response); err != nil {
+		return nil, err
 	}
 	tenant := response.Data.Props
 	tenant.BaseAzureEntity = response.Data.BaseEntity
@@ -209,16 +125,476 @@ func (c *Client) GetAzureTenant(objectID string) (*AzureTenant, error) {
 
 // GetAzureTenantByName fetches a single Azure tenant by its name.
 func (c *Client) GetAzureTenantByName(tenantName string) (*AzureTenant, error) {
-	searchResponse, err := c.Search(tenantName, "AZTenant", 0)
+	objectID, err := c.resolveObjectID("AZTenant", tenantName)
 	if err != nil {
 		return nil, err
 	}
+	return c.GetAzureTenant(objectID)
+}
+
+// AzureEntityType is the path segment the API uses for an Azure entity kind.
+type AzureEntityType string
+
+const (
+	AzureUsers               AzureEntityType = "users"
+	AzureGroups              AzureEntityType = "groups"
+	AzureTenants             AzureEntityType = "tenants"
+	AzureVMs                 AzureEntityType = "vms"
+	AzureServicePrincipals   AzureEntityType = "service-principals"
+	AzureApps                AzureEntityType = "apps"
+	AzureDevices             AzureEntityType = "devices"
+	AzureRoles               AzureEntityType = "roles"
+	AzureManagementGroups    AzureEntityType = "management-groups"
+	AzureSubscriptions       AzureEntityType = "subscriptions"
+	AzureResourceGroups      AzureEntityType = "resource-groups"
+	AzureKeyVaults           AzureEntityType = "key-vaults"
+	AzureAutomationAccounts  AzureEntityType = "automation-accounts"
+	AzureContainerRegistries AzureEntityType = "container-registries"
+	AzureFunctionApps        AzureEntityType = "function-apps"
+	AzureLogicApps           AzureEntityType = "logic-apps"
+	AzureWebApps             AzureEntityType = "web-apps"
+	AzureManagedClusters     AzureEntityType = "managed-clusters"
+	AzureVMScaleSets         AzureEntityType = "vm-scale-sets"
+)
+
+// AzureResourceID is a parsed Azure Resource Manager ID, the Object ID of management
+// groups, subscriptions, resource groups and the resources in them. BloodHound stores
+// only the common Azure properties for most of these kinds, so the subscription and
+// resource group of a resource are read from its ID.
+type AzureResourceID struct {
+	SubscriptionID string
+	ResourceGroup  string
+	// Provider is the resource provider namespace, e.g. "Microsoft.Web".
+	Provider string
+	// ResourceType is the type within the provider, e.g. "sites" or "sites/slots".
+	ResourceType string
+	// Name is the name of the last resource in the ID.
+	Name string
+}
+
+// ParseAzureResourceID parses an Azure Resource Manager ID such as
+// "/subscriptions/<id>/resourceGroups/<name>/providers/Microsoft.Web/sites/<name>".
+// Segment names are matched case-insensitively, since BloodHound upper-cases Object IDs.
+// It reports false for IDs that are not resource IDs, e.g. the GUIDs of Entra ID objects.
+func ParseAzureResourceID(id string) (AzureResourceID, bool) {
+	var segments []string
+	for _, segment := range strings.Split(id, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if !strings.HasPrefix(id, "/") || len(segments) < 2 {
+		return AzureResourceID{}, false
+	}
 
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, tenantName) {
-			return c.GetAzureTenant(result.ObjectID)
+	var parsed AzureResourceID
+	var types []string
+	for i := 0; i < len(segments); i++ {
+		key := segments[i]
+		switch {
+		case strings.EqualFold(key, "providers") && i+1 < len(segments):
+			parsed.Provider = segments[i+1]
+			i++
+		case i+1 >= len(segments):
+			return AzureResourceID{}, false
+		case parsed.Provider == "" && strings.EqualFold(key, "subscriptions"):
+			parsed.SubscriptionID = segments[i+1]
+			i++
+		case parsed.Provider == "" && strings.EqualFold(key, "resourceGroups"):
+			parsed.ResourceGroup = segments[i+1]
+			parsed.Name = segments[i+1]
+			i++
+		case parsed.Provider != "":
+			types = append(types, key)
+			parsed.Name = segments[i+1]
+			i++
+		default:
+			return AzureResourceID{}, false
 		}
 	}
+	if parsed.SubscriptionID != "" && parsed.Name == "" {
+		parsed.Name = parsed.SubscriptionID
+	}
+	parsed.ResourceType = strings.Join(types, "/")
+	return parsed, true
+}
+
+// ResourceID parses the entity's Object ID as an Azure Resource Manager ID.
+func (e BaseAzureEntity) ResourceID() (AzureResourceID, bool) {
+	return ParseAzureResourceID(e.ObjectID)
+}
+
+// AzureRelatedEntityType selects a list of entities related to an Azure entity.
+// Not every relation applies to every entity type; the server rejects unsupported combinations.
+type AzureRelatedEntityType string
+
+const (
+	AzureRelatedInboundObjectControl               AzureRelatedEntityType = "inbound-object-control"
+	AzureRelatedOutboundObjectControl              AzureRelatedEntityType = "outbound-object-control"
+	AzureRelatedInboundExecutionPrivileges         AzureRelatedEntityType = "inbound-execution-privileges"
+	AzureRelatedOutboundExecutionPrivileges        AzureRelatedEntityType = "outbound-execution-privileges"
+	AzureRelatedMemberOf                           AzureRelatedEntityType = "member-of"
+	AzureRelatedGroupMembers                       AzureRelatedEntityType = "group-members"
+	AzureRelatedRoles                              AzureRelatedEntityType = "roles"
+	AzureRelatedActiveAssignments                  AzureRelatedEntityType = "active-assignments"
+	AzureRelatedPIMAssignments                     AzureRelatedEntityType = "pim-assignments"
+	AzureRelatedRoleApprovers                      AzureRelatedEntityType = "role-approvers"
+	AzureRelatedInboundAbusableAppRoleAssignments  AzureRelatedEntityType = "inbound-abusable-app-role-assignments"
+	AzureRelatedOutboundAbusableAppRoleAssignments AzureRelatedEntityType = "outbound-abusable-app-role-assignments"
+	AzureRelatedKeyReaders                         AzureRelatedEntityType = "key-readers"
+	AzureRelatedCertificateReaders                 AzureRelatedEntityType = "certificate-readers"
+	AzureRelatedSecretReaders                      AzureRelatedEntityType = "secret-readers"
+	AzureRelatedAllReaders                         AzureRelatedEntityType = "all-readers"
+	AzureRelatedDescendentUsers                    AzureRelatedEntityType = "descendent-users"
+	AzureRelatedDescendentGroups                   AzureRelatedEntityType = "descendent-groups"
+	AzureRelatedDescendentManagementGroups         AzureRelatedEntityType = "descendent-management-groups"
+	AzureRelatedDescendentSubscriptions            AzureRelatedEntityType = "descendent-subscriptions"
+	AzureRelatedDescendentResourceGroups           AzureRelatedEntityType = "descendent-resource-groups"
+	AzureRelatedDescendentVMs                      AzureRelatedEntityType = "descendent-virtual-machines"
+	AzureRelatedDescendentManagedClusters          AzureRelatedEntityType = "descendent-managed-clusters"
+	AzureRelatedDescendentVMScaleSets              AzureRelatedEntityType = "descendent-vm-scale-sets"
+	AzureRelatedDescendentContainerRegistries      AzureRelatedEntityType = "descendent-container-registries"
+	AzureRelatedDescendentAutomationAccounts       AzureRelatedEntityType = "descendent-automation-accounts"
+	AzureRelatedDescendentKeyVaults                AzureRelatedEntityType = "descendent-key-vaults"
+	AzureRelatedDescendentFunctionApps             AzureRelatedEntityType = "descendent-function-apps"
+	AzureRelatedDescendentLogicApps                AzureRelatedEntityType = "descendent-logic-apps"
+	AzureRelatedDescendentWebApps                  AzureRelatedEntityType = "descendent-web-apps"
+	AzureRelatedDescendentServicePrincipals        AzureRelatedEntityType = "descendent-service-principals"
+	AzureRelatedDescendentApplications             AzureRelatedEntityType = "descendent-applications"
+	AzureRelatedDescendentDevices                  AzureRelatedEntityType = "descendent-devices"
+)
+
+// getAzureEntityProps fetches the properties of an Azure entity into props.
+func (c *Client) getAzureEntityProps(entityType AzureEntityType, objectID string, props interface{}) error {
+	apiUrl := c.baseURL.JoinPath("/api/v2/azure/", string(entityType))
+	params := url.Values{}
+	params.Add("object_id", objectID)
+	params.Add("counts", "true")
+	apiUrl.RawQuery = params.Encode()
+
+	var response struct {
+		Data struct {
+			Props json.RawMessage `json:"props"`
+		} `json:"data"`
+	}
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(response.Data.Props, props); err != nil {
+		return fmt.Errorf("failed to decode azure %s properties: %w", entityType, err)
+	}
+	return nil
+}
+
+// GetAzureRelatedEntities fetches a page of the entities related to an Azure entity,
+// e.g. the inbound object controllers of a user or the active assignments of a role.
+func (c *Client) GetAzureRelatedEntities(entityType AzureEntityType, objectID string, related AzureRelatedEntityType, skip, limit int) (AzureRelatedEntitiesResponse, error) {
+	var rawResponse AzureRelatedEntitiesResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/azure/", string(entityType))
+	params := url.Values{}
+	params.Add("object_id", objectID)
+	params.Add("related_entity_type", string(related))
+	params.Add("type", "list")
+	if skip > 0 {
+		params.Add("skip", fmt.Sprintf("%d", skip))
+	}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
+	}
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// ListAllAzureRelatedEntities pages through every entity related to an Azure entity.
+func (c *Client) ListAllAzureRelatedEntities(entityType AzureEntityType, objectID string, related AzureRelatedEntityType) ([]GraphNodeProperties, error) {
+	return listAll(listPageSize, func(skip, limit int) ([]GraphNodeProperties, int, error) {
+		page, err := c.GetAzureRelatedEntities(entityType, objectID, related, skip, limit)
+		return page.Data, page.Count, err
+	})
+}
+
+// GetAzureServicePrincipal fetches a single Azure service principal by its Object ID.
+func (c *Client) GetAzureServicePrincipal(objectID string) (*AzureServicePrincipal, error) {
+	var servicePrincipal AzureServicePrincipal
+	if err := c.getAzureEntityProps(AzureServicePrincipals, objectID, &servicePrincipal); err != nil {
+		return nil, err
+	}
+	servicePrincipal.ObjectType = "AZServicePrincipal"
+	return &servicePrincipal, nil
+}
+
+// GetAzureServicePrincipalByName fetches a single Azure service principal by its name.
+func (c *Client) GetAzureServicePrincipalByName(name string) (*AzureServicePrincipal, error) {
+	objectID, err := c.resolveObjectID("AZServicePrincipal", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureServicePrincipal(objectID)
+}
+
+// GetAzureApp fetches a single Azure application by its Object ID.
+func (c *Client) GetAzureApp(objectID string) (*AzureApp, error) {
+	var app AzureApp
+	if err := c.getAzureEntityProps(AzureApps, objectID, &app); err != nil {
+		return nil, err
+	}
+	app.ObjectType = "AZApp"
+	return &app, nil
+}
+
+// GetAzureAppByName fetches a single Azure application by its name.
+func (c *Client) GetAzureAppByName(name string) (*AzureApp, error) {
+	objectID, err := c.resolveObjectID("AZApp", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureApp(objectID)
+}
+
+// GetAzureDevice fetches a single Azure device by its Object ID.
+func (c *Client) GetAzureDevice(objectID string) (*AzureDevice, error) {
+	var device AzureDevice
+	if err := c.getAzureEntityProps(AzureDevices, objectID, &device); err != nil {
+		return nil, err
+	}
+	device.ObjectType = "AZDevice"
+	return &device, nil
+}
+
+// GetAzureDeviceByName fetches a single Azure device by its name.
+func (c *Client) GetAzureDeviceByName(name string) (*AzureDevice, error) {
+	objectID, err := c.resolveObjectID("AZDevice", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureDevice(objectID)
+}
+
+// GetAzureRole fetches a single Azure role by its Object ID.
+func (c *Client) GetAzureRole(objectID string) (*AzureRole, error) {
+	var role AzureRole
+	if err := c.getAzureEntityProps(AzureRoles, objectID, &role); err != nil {
+		return nil, err
+	}
+	role.ObjectType = "AZRole"
+	return &role, nil
+}
+
+// GetAzureRoleByName fetches a single Azure role by its name.
+func (c *Client) GetAzureRoleByName(name string) (*AzureRole, error) {
+	objectID, err := c.resolveObjectID("AZRole", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureRole(objectID)
+}
+
+// GetAzureManagementGroup fetches a single Azure management group by its Object ID.
+func (c *Client) GetAzureManagementGroup(objectID string) (*AzureManagementGroup, error) {
+	var managementGroup AzureManagementGroup
+	if err := c.getAzureEntityProps(AzureManagementGroups, objectID, &managementGroup); err != nil {
+		return nil, err
+	}
+	managementGroup.ObjectType = "AZManagementGroup"
+	return &managementGroup, nil
+}
+
+// GetAzureManagementGroupByName fetches a single Azure management group by its name.
+func (c *Client) GetAzureManagementGroupByName(name string) (*AzureManagementGroup, error) {
+	objectID, err := c.resolveObjectID("AZManagementGroup", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureManagementGroup(objectID)
+}
+
+// GetAzureSubscription fetches a single Azure subscription by its Object ID.
+func (c *Client) GetAzureSubscription(objectID string) (*AzureSubscription, error) {
+	var subscription AzureSubscription
+	if err := c.getAzureEntityProps(AzureSubscriptions, objectID, &subscription); err != nil {
+		return nil, err
+	}
+	subscription.ObjectType = "AZSubscription"
+	return &subscription, nil
+}
+
+// GetAzureSubscriptionByName fetches a single Azure subscription by its name.
+func (c *Client) GetAzureSubscriptionByName(name string) (*AzureSubscription, error) {
+	objectID, err := c.resolveObjectID("AZSubscription", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureSubscription(objectID)
+}
+
+// GetAzureResourceGroup fetches a single Azure resource group by its Object ID.
+func (c *Client) GetAzureResourceGroup(objectID string) (*AzureResourceGroup, error) {
+	var resourceGroup AzureResourceGroup
+	if err := c.getAzureEntityProps(AzureResourceGroups, objectID, &resourceGroup); err != nil {
+		return nil, err
+	}
+	resourceGroup.ObjectType = "AZResourceGroup"
+	return &resourceGroup, nil
+}
+
+// GetAzureResourceGroupByName fetches a single Azure resource group by its name.
+func (c *Client) GetAzureResourceGroupByName(name string) (*AzureResourceGroup, error) {
+	objectID, err := c.resolveObjectID("AZResourceGroup", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureResourceGroup(objectID)
+}
+
+// GetAzureKeyVault fetches a single Azure key vault by its Object ID.
+func (c *Client) GetAzureKeyVault(objectID string) (*AzureKeyVault, error) {
+	var keyVault AzureKeyVault
+	if err := c.getAzureEntityProps(AzureKeyVaults, objectID, &keyVault); err != nil {
+		return nil, err
+	}
+	keyVault.ObjectType = "AZKeyVault"
+	return &keyVault, nil
+}
+
+// GetAzureKeyVaultByName fetches a single Azure key vault by its name.
+func (c *Client) GetAzureKeyVaultByName(name string) (*AzureKeyVault, error) {
+	objectID, err := c.resolveObjectID("AZKeyVault", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureKeyVault(objectID)
+}
+
+// GetAzureAutomationAccount fetches a single Azure automation account by its Object ID.
+func (c *Client) GetAzureAutomationAccount(objectID string) (*AzureAutomationAccount, error) {
+	var automationAccount AzureAutomationAccount
+	if err := c.getAzureEntityProps(AzureAutomationAccounts, objectID, &automationAccount); err != nil {
+		return nil, err
+	}
+	automationAccount.ObjectType = "AZAutomationAccount"
+	return &automationAccount, nil
+}
+
+// GetAzureAutomationAccountByName fetches a single Azure automation account by its name.
+func (c *Client) GetAzureAutomationAccountByName(name string) (*AzureAutomationAccount, error) {
+	objectID, err := c.resolveObjectID("AZAutomationAccount", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureAutomationAccount(objectID)
+}
+
+// GetAzureContainerRegistry fetches a single Azure container registry by its Object ID.
+func (c *Client) GetAzureContainerRegistry(objectID string) (*AzureContainerRegistry, error) {
+	var containerRegistry AzureContainerRegistry
+	if err := c.getAzureEntityProps(AzureContainerRegistries, objectID, &containerRegistry); err != nil {
+		return nil, err
+	}
+	containerRegistry.ObjectType = "AZContainerRegistry"
+	return &containerRegistry, nil
+}
+
+// GetAzureContainerRegistryByName fetches a single Azure container registry by its name.
+func (c *Client) GetAzureContainerRegistryByName(name string) (*AzureContainerRegistry, error) {
+	objectID, err := c.resolveObjectID("AZContainerRegistry", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureContainerRegistry(objectID)
+}
+
+// GetAzureFunctionApp fetches a single Azure function app by its Object ID.
+func (c *Client) GetAzureFunctionApp(objectID string) (*AzureFunctionApp, error) {
+	var functionApp AzureFunctionApp
+	if err := c.getAzureEntityProps(AzureFunctionApps, objectID, &functionApp); err != nil {
+		return nil, err
+	}
+	functionApp.ObjectType = "AZFunctionApp"
+	return &functionApp, nil
+}
+
+// GetAzureFunctionAppByName fetches a single Azure function app by its name.
+func (c *Client) GetAzureFunctionAppByName(name string) (*AzureFunctionApp, error) {
+	objectID, err := c.resolveObjectID("AZFunctionApp", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureFunctionApp(objectID)
+}
+
+// GetAzureLogicApp fetches a single Azure logic app by its Object ID.
+func (c *Client) GetAzureLogicApp(objectID string) (*AzureLogicApp, error) {
+	var logicApp AzureLogicApp
+	if err := c.getAzureEntityProps(AzureLogicApps, objectID, &logicApp); err != nil {
+		return nil, err
+	}
+	logicApp.ObjectType = "AZLogicApp"
+	return &logicApp, nil
+}
 
-	return nil, fmt.Errorf("azure tenant not found: %s", tenantName)
+// GetAzureLogicAppByName fetches a single Azure logic app by its name.
+func (c *Client) GetAzureLogicAppByName(name string) (*AzureLogicApp, error) {
+	objectID, err := c.resolveObjectID("AZLogicApp", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureLogicApp(objectID)
+}
+
+// GetAzureWebApp fetches a single Azure web app by its Object ID.
+func (c *Client) GetAzureWebApp(objectID string) (*AzureWebApp, error) {
+	var webApp AzureWebApp
+	if err := c.getAzureEntityProps(AzureWebApps, objectID, &webApp); err != nil {
+		return nil, err
+	}
+	webApp.ObjectType = "AZWebApp"
+	return &webApp, nil
+}
+
+// GetAzureWebAppByName fetches a single Azure web app by its name.
+func (c *Client) GetAzureWebAppByName(name string) (*AzureWebApp, error) {
+	objectID, err := c.resolveObjectID("AZWebApp", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureWebApp(objectID)
+}
+
+// GetAzureManagedCluster fetches a single Azure managed cluster by its Object ID.
+func (c *Client) GetAzureManagedCluster(objectID string) (*AzureManagedCluster, error) {
+	var managedCluster AzureManagedCluster
+	if err := c.getAzureEntityProps(AzureManagedClusters, objectID, &managedCluster); err != nil {
+		return nil, err
+	}
+	managedCluster.ObjectType = "AZManagedCluster"
+	return &managedCluster, nil
+}
+
+// GetAzureManagedClusterByName fetches a single Azure managed cluster by its name.
+func (c *Client) GetAzureManagedClusterByName(name string) (*AzureManagedCluster, error) {
+	objectID, err := c.resolveObjectID("AZManagedCluster", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureManagedCluster(objectID)
+}
+
+// GetAzureVMScaleSet fetches a single Azure VM scale set by its Object ID.
+func (c *Client) GetAzureVMScaleSet(objectID string) (*AzureVMScaleSet, error) {
+	var vMScaleSet AzureVMScaleSet
+	if err := c.getAzureEntityProps(AzureVMScaleSets, objectID, &vMScaleSet); err != nil {
+		return nil, err
+	}
+	vMScaleSet.ObjectType = "AZVMScaleSet"
+	return &vMScaleSet, nil
+}
+
+// GetAzureVMScaleSetByName fetches a single Azure VM scale set by its name.
+func (c *Client) GetAzureVMScaleSetByName(name string) (*AzureVMScaleSet, error) {
+	objectID, err := c.resolveObjectID("AZVMScaleSet", name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAzureVMScaleSet(objectID)
 }
@@ -1,8 +1,11 @@
 package bloodhound
 
 import (
+	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +18,8 @@ type Client struct {
 	baseURL    *url.URL
 	httpClient *http.Client
 	token      string
+
+	entityFetches flightGroup // deduplicates concurrent GetEntity calls during hydration
 }
 
 // NewClient creates and returns a new BloodHound API client.
@@ -89,14 +94,14 @@ func (c *Client) do(req *http.Request, sanitizedBody []byte) (*http.Response, er
 			return nil, fmt.Errorf("failed to read error response body: %w", err)
 		}
 
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 		var errorResponse ErrorResponse
 		if err := json.Unmarshal(body, &errorResponse); err == nil {
 			if len(errorResponse.Errors) > 0 {
-				return nil, fmt.Errorf("API error: %s", errorResponse.Errors[0].Message)
+				apiErr.Message = errorResponse.Errors[0].Message
 			}
 		}
-		// Fallback to a generic error if parsing fails or there are no specific messages
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, apiErr
 	}
 
 	// Decompress gzipped responses
@@ -109,4 +114,77 @@ func (c *Client) do(req *http.Request, sanitizedBody []byte) (*http.Response, er
 	}
 
 	return resp, nil
-}
\ No newline at end of file
+}
+
+// APIError is returned for responses with an error status code. A 404 matches ErrNotFound
+// with errors.Is.
+type APIError struct {
+	StatusCode int
+	// Message is the first error message reported by the server, if any.
+	Message string
+	Body    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API error: %s", e.Message)
+	}
+	// Fallback to a generic error if parsing fails or there are no specific messages
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is reports whether the error matches target; a 404 matches ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// listPageSize is the number of items fetched per request by the ListAll* helpers.
+const listPageSize = 500
+
+// listAll pages through a listing with skip and limit until a short page comes back. The
+// total reported by fetch is only trusted when positive, since some endpoints omit it.
+func listAll[T any](pageSize int, fetch func(skip, limit int) (page []T, count int, err error)) ([]T, error) {
+	var items []T
+	for skip := 0; ; skip += pageSize {
+		page, count, err := fetch(skip, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < pageSize || (count > 0 && len(items) >= count) {
+			return items, nil
+		}
+	}
+}
+
+// doJSON executes an authenticated request bound to ctx. A non-nil body is sent as JSON and,
+// when out is non-nil, the response body is decoded into it.
+func (c *Client) doJSON(ctx context.Context, method string, apiUrl *url.URL, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s request: %w", method, apiUrl.Path, err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := c.newAuthenticatedRequest(method, apiUrl.String(), reader)
+	if err != nil {
+		return fmt.Errorf("failed to create %s %s request: %w", method, apiUrl.Path, err)
+	}
+
+	resp, err := c.do(req.WithContext(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s %s request: %w", method, apiUrl.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, apiUrl.Path, err)
+	}
+	return nil
+}
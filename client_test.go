@@ -0,0 +1,154 @@
+package bloodhound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestClient starts a server for handler and returns an authenticated client for it.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetToken("test-session-token")
+	return client
+}
+
+// writeTestJSON writes a JSON response body with the given status code.
+func writeTestJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+// cypherHandler answers Cypher queries with the response of the first key contained in
+// the query, or with BloodHound's 404 for queries without results.
+func cypherHandler(t *testing.T, responses map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var query CypherQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			t.Errorf("failed to decode cypher request: %v", err)
+		}
+		for key, response := range responses {
+			if strings.Contains(query.Query, key) {
+				writeTestJSON(w, http.StatusOK, `{"data": `+response+`}`)
+				return
+			}
+		}
+		writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+	}
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", cypherHandler(t, map[string]string{
+		"RETURN n": `{"nodes": {"1": {"label": "ALICE@CORP.LOCAL", "kind": "User", "objectId": "S-1-5-21-1-1104"}}, "edges": []}`,
+	}))
+	mux.HandleFunc("/api/v2/graphs/shortest-path", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusNotFound, `{"errors": [{"message": "Path not found"}]}`)
+	})
+	mux.HandleFunc("/api/v2/broken", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusInternalServerError, `{"errors": [{"message": "boom"}]}`)
+	})
+	client := newTestClient(t, mux)
+
+	graph, err := client.RunCypherGraph(context.Background(), "MATCH (n) RETURN n")
+	if err != nil || len(graph.Nodes) != 1 {
+		t.Fatalf("RunCypherGraph() = %+v, %v", graph, err)
+	}
+	graph, err = client.RunCypherGraph(context.Background(), "MATCH (n:Nothing) RETURN m")
+	if err != nil || len(graph.Nodes) != 0 || len(graph.Edges) != 0 {
+		t.Errorf("empty RunCypherGraph() = %+v, %v", graph, err)
+	}
+	if raw, err := client.RunCypherQuery("MATCH (n:Nothing) RETURN m"); err != nil || string(raw) != "{}" {
+		t.Errorf("empty RunCypherQuery() = %s, %v", raw, err)
+	}
+
+	if _, err := client.GetShortestPath("A", "B", ""); !errors.Is(err, ErrNotFound) || err.Error() != "path not found" {
+		t.Errorf("GetShortestPath() error = %v, want path not found", err)
+	}
+
+	err = client.doJSON(context.Background(), http.MethodGet, client.baseURL.JoinPath("/api/v2/broken"), nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || apiErr.Message != "boom" || errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error for a 500: %v", err)
+	}
+}
+
+func TestGeneratedEndpoints(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/version":
+			writeTestJSON(w, http.StatusOK, `{"data": {"API": {"current_version": "v2"}, "server_version": "v8.0.0"}}`)
+		case "/api/v2/features":
+			writeTestJSON(w, http.StatusOK, `{"data": [{"id": 1, "key": "adcs", "enabled": true}]}`)
+		default:
+			writeTestJSON(w, http.StatusNotFound, `{"errors": [{"message": "resource not found"}]}`)
+		}
+	}))
+
+	version, err := client.RawGetAPIVersion(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Data == nil || version.Data.ServerVersion != "v8.0.0" || version.Data.API.CurrentVersion != "v2" {
+		t.Errorf("unexpected version: %+v", version.Data)
+	}
+
+	features, err := client.RawGetFeatureFlags(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(features.Data) != 1 || features.Data[0].Key != "adcs" || !features.Data[0].Enabled {
+		t.Errorf("unexpected feature flags: %+v", features.Data)
+	}
+
+	if err := client.RawRequestAnalysis(context.Background(), nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RawRequestAnalysis() = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		count    func(total int) int
+		requests int
+	}{
+		{"count reported", 7, func(total int) int { return total }, 3},
+		{"count omitted", 7, func(int) int { return 0 }, 3},
+		{"exact multiple with count", 6, func(total int) int { return total }, 2},
+		{"exact multiple without count", 6, func(int) int { return 0 }, 3},
+		{"empty", 0, func(int) int { return 0 }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			items, err := listAll(3, func(skip, limit int) ([]int, int, error) {
+				requests++
+				var page []int
+				for i := skip; i < skip+limit && i < tt.total; i++ {
+					page = append(page, i)
+				}
+				return page, tt.count(tt.total), nil
+			})
+			if err != nil || len(items) != tt.total || requests != tt.requests {
+				t.Errorf("got %d items in %d requests (err %v), want %d in %d", len(items), requests, err, tt.total, tt.requests)
+			}
+		})
+	}
+
+	want := errors.New("page failed")
+	if _, err := listAll(3, func(skip, limit int) ([]int, int, error) { return nil, 0, want }); err != want {
+		t.Errorf("listAll returned %v, want %v", err, want)
+	}
+}
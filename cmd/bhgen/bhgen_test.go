@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+	"paths": {
+		"/api/v2/users/{object_id}/sessions": {
+			"get": {
+				"operationId": "GetUserEntitySessions",
+				"parameters": [{"name": "object_id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"responses": {"200": {"content": {"application/json": {"schema": {
+					"type": "object",
+					"properties": {"count": {"type": "integer"}, "data": {"type": "array", "items": {"$ref": "#/components/schemas/model.SavedQuery"}}}
+				}}}}}
+			}
+		},
+		"/api/v2/saved-queries": {
+			"post": {
+				"operationId": "CreateSavedQuery",
+				"requestBody": {"content": {}},
+				"responses": {"201": {"$ref": "#/components/responses/saved-query"}}
+			}
+		},
+		"/api/v2/saved-queries/{saved_query_id}": {
+			"delete": {"operationId": "DeleteSavedQuery", "responses": {"204": {"description": "No Content"}}}
+		}
+	},
+	"components": {
+		"responses": {
+			"saved-query": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/model.SavedQuery"}}}}
+		},
+		"schemas": {
+			"model.SavedQuery": {
+				"type": "object",
+				"properties": {"id": {"type": "integer", "format": "int64"}, "name": {"type": "string"}}
+			}
+		}
+	}
+}`
+
+func loadTestSpec(t *testing.T) *document {
+	t.Helper()
+	var doc document
+	if err := json.Unmarshal([]byte(testSpec), &doc); err != nil {
+		t.Fatalf("failed to parse test spec: %v", err)
+	}
+	return &doc
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := generate(loadTestSpec(t), "bloodhound")
+	if err != nil {
+		t.Fatalf("generate() returned an error: %v", err)
+	}
+	for _, want := range []string{
+		"DO NOT EDIT",
+		"type APIModelSavedQuery struct",
+		"type APIGetUserEntitySessionsResponse struct",
+		"Data  []APIModelSavedQuery `json:\"data,omitempty\"`",
+		"func (c *Client) RawGetUserEntitySessions(ctx context.Context, objectID string, query url.Values) (*APIGetUserEntitySessionsResponse, error)",
+		"func (c *Client) RawCreateSavedQuery(ctx context.Context, query url.Values, body interface{}) (*APIModelSavedQuery, error)",
+		"func (c *Client) RawDeleteSavedQuery(ctx context.Context, savedQueryID string, query url.Values) error",
+	} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("generated source does not contain %q", want)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	dir := t.TempDir()
+	src := `package bloodhound
+
+func (c *Client) GetUserSessions(id string) { c.baseURL.JoinPath("/api/v2/users/", id, "/sessions") }
+func (c *Client) ListThings() { c.baseURL.JoinPath("/api/v2/things") }
+`
+	if err := os.WriteFile(filepath.Join(dir, "users.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := coverage(loadTestSpec(t), dir)
+	if err != nil {
+		t.Fatalf("coverage() returned an error: %v", err)
+	}
+	if report.Total != 3 || report.Covered != 1 {
+		t.Errorf("expected 1 of 3 endpoints covered, got %d of %d", report.Covered, report.Total)
+	}
+	if len(report.Missing) != 2 || report.Missing[0] != "POST /api/v2/saved-queries" {
+		t.Errorf("unexpected missing endpoints: %v", report.Missing)
+	}
+	if len(report.Unknown) != 1 || report.Unknown[0] != "/api/v2/things" {
+		t.Errorf("unexpected unknown client paths: %v", report.Unknown)
+	}
+}
+
+func TestCoverageFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		report coverageReport
+		strict bool
+		want   bool
+	}{
+		{"complete", coverageReport{Total: 1, Covered: 1}, true, false},
+		{"missing", coverageReport{Total: 1, Missing: []string{"GET /api/v2/things"}}, false, false},
+		{"missing strict", coverageReport{Total: 1, Missing: []string{"GET /api/v2/things"}}, true, true},
+		{"unknown", coverageReport{Total: 1, Covered: 1, Unknown: []string{"/api/v2/things"}}, false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.report.failed(tt.strict); got != tt.want {
+			t.Errorf("%s: failed(%v) = %v, want %v", tt.name, tt.strict, got, tt.want)
+		}
+	}
+}
+
+func TestFetchSpec(t *testing.T) {
+	body := testSpec
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	if err := fetchSpec(server.URL, path); err != nil {
+		t.Fatalf("fetchSpec() returned an error: %v", err)
+	}
+	doc, err := loadSpec(path)
+	if err != nil || len(doc.Paths) != 3 {
+		t.Fatalf("expected the fetched spec with 3 paths, got %v", err)
+	}
+
+	// A download that is not a document leaves the existing file alone.
+	body = `<html>rate limited</html>`
+	if err := fetchSpec(server.URL, path); err == nil {
+		t.Error("expected an error for a download that is not a document")
+	}
+	if doc, err := loadSpec(path); err != nil || len(doc.Paths) != 3 {
+		t.Errorf("expected the previous spec to be kept, got %v", err)
+	}
+}
+
+// TestVendoredSpecCoverage checks that every endpoint the hand-written client calls is in
+// the vendored OpenAPI document.
+func TestVendoredSpecCoverage(t *testing.T) {
+	doc, err := loadSpec(filepath.Join("..", "..", "openapi", "openapi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Info.Subset {
+		t.Skip("openapi/openapi.json is not the official document; run go generate to fetch it")
+	}
+	report, err := coverage(doc, filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("coverage() returned an error: %v", err)
+	}
+	if len(report.Unknown) > 0 {
+		t.Errorf("the client calls paths that are not in the spec:\n  %s", strings.Join(report.Unknown, "\n  "))
+	}
+}
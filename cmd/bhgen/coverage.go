@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// coverageReport lists the spec endpoints the hand-written client does and does not call.
+type coverageReport struct {
+	Total   int
+	Covered int
+	Missing []string // "METHOD /path" of spec endpoints no client path matches
+	Unknown []string // client paths that match no spec endpoint
+}
+
+// failed reports whether the coverage check fails: always when the client calls a path the
+// spec does not have, and with strict also when a spec endpoint is missing from the client.
+func (r coverageReport) failed(strict bool) bool {
+	return len(r.Unknown) > 0 || (strict && len(r.Missing) > 0)
+}
+
+func (r coverageReport) String() string {
+	var b strings.Builder
+	percent := 0.0
+	if r.Total > 0 {
+		percent = 100 * float64(r.Covered) / float64(r.Total)
+	}
+	fmt.Fprintf(&b, "Spec endpoints: %d, covered: %d (%.1f%%)\n", r.Total, r.Covered, percent)
+	if len(r.Missing) > 0 {
+		fmt.Fprintf(&b, "\nMissing from the client:\n")
+		for _, missing := range r.Missing {
+			fmt.Fprintf(&b, "  %s\n", missing)
+		}
+	}
+	if len(r.Unknown) > 0 {
+		fmt.Fprintf(&b, "\nCalled by the client but not in the spec:\n")
+		for _, unknown := range r.Unknown {
+			fmt.Fprintf(&b, "  %s\n", unknown)
+		}
+	}
+	return b.String()
+}
+
+// coverage matches the endpoints of the spec against the API paths built in the hand-written
+// sources of srcDir. Paths are compared segment by segment with parameters as wildcards;
+// the HTTP method is not taken into account.
+func coverage(doc *document, srcDir string) (coverageReport, error) {
+	var report coverageReport
+
+	endpoints, err := doc.endpoints()
+	if err != nil {
+		return report, err
+	}
+	clientPaths, err := clientPaths(srcDir)
+	if err != nil {
+		return report, err
+	}
+
+	used := map[string]bool{}
+	for _, ep := range endpoints {
+		report.Total++
+		specPath := normalizePath(ep.Path)
+		found := false
+		for _, clientPath := range clientPaths {
+			if pathsMatch(specPath, clientPath) {
+				found = true
+				used[clientPath] = true
+			}
+		}
+		if found {
+			report.Covered++
+		} else {
+			report.Missing = append(report.Missing, ep.Method+" "+ep.Path)
+		}
+	}
+	for _, clientPath := range clientPaths {
+		if !used[clientPath] {
+			report.Unknown = append(report.Unknown, "/"+clientPath)
+		}
+	}
+	sort.Strings(report.Unknown)
+	return report, nil
+}
+
+// normalizePath strips the leading slash and replaces every {param} segment with {}.
+func normalizePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathsMatch(a, b string) bool {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	if len(as) != len(bs) {
+		return false
+	}
+	for i := range as {
+		if as[i] != bs[i] && as[i] != "{}" && bs[i] != "{}" {
+			return false
+		}
+	}
+	return true
+}
+
+// clientPaths collects the normalized /api/v2 paths passed to JoinPath in the non-test,
+// non-generated Go files of dir.
+func clientPaths(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var paths []string
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(string(src), "Code generated") && strings.Contains(string(src), "DO NOT EDIT") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(parsed, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || selector.Sel.Name != "JoinPath" {
+				return true
+			}
+			path := joinPathPattern(call.Args)
+			if strings.HasPrefix(path, "api/v2") && !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+			return true
+		})
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// joinPathPattern renders the arguments of a JoinPath call as a path, using {} for
+// every segment that is not a string literal.
+func joinPathPattern(args []ast.Expr) string {
+	var segments []string
+	for _, arg := range args {
+		segments = append(segments, exprSegments(arg)...)
+	}
+	return strings.Join(segments, "/")
+}
+
+func exprSegments(expr ast.Expr) []string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if value, err := strconv.Unquote(e.Value); err == nil && e.Kind == token.STRING {
+			return splitSegments(value)
+		}
+	case *ast.CallExpr:
+		// fmt.Sprintf("/api/v2/saved-queries/%d", id)
+		if selector, ok := e.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "Sprintf" && len(e.Args) > 0 {
+			if lit, ok := e.Args[0].(*ast.BasicLit); ok {
+				if format, err := strconv.Unquote(lit.Value); err == nil {
+					segments := splitSegments(format)
+					for i, segment := range segments {
+						if strings.Contains(segment, "%") {
+							segments[i] = "{}"
+						}
+					}
+					return segments
+				}
+			}
+		}
+	}
+	return []string{"{}"}
+}
+
+func splitSegments(value string) []string {
+	var segments []string
+	for _, segment := range strings.Split(value, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// initialisms are rendered in upper case when they form a whole word of an identifier.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "DN": true, "GPO": true, "HTTP": true, "ID": true, "IDS": true,
+	"JSON": true, "OU": true, "OID": true, "SAML": true, "SID": true, "SPN": true, "SQL": true,
+	"SSO": true, "UPN": true, "URL": true, "URI": true, "UUID": true,
+}
+
+// identifier turns an OpenAPI name (snake_case, kebab-case, dotted or camelCase) into an exported Go identifier.
+func identifier(name string) string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	id := b.String()
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "X" + id
+	}
+	return id
+}
+
+// argument turns a parameter name into an unexported Go identifier that is safe to use as an argument.
+func argument(name string) string {
+	id := []rune(identifier(name))
+	n := 0
+	for n < len(id) && unicode.IsUpper(id[n]) {
+		n++
+	}
+	if n > 1 && n < len(id) {
+		n-- // keep the capital that starts the next word, e.g. SIDValue -> sidValue
+	}
+	for i := 0; i < n; i++ {
+		id[i] = unicode.ToLower(id[i])
+	}
+	arg := string(id)
+	if token.IsKeyword(arg) || arg == "ctx" || arg == "query" || arg == "body" || arg == "c" {
+		arg += "Param"
+	}
+	return arg
+}
+
+// modelName returns the Go type name for a component schema. Schemas of the api package
+// are not prefixed twice.
+func modelName(name string) string {
+	id := identifier(name)
+	if strings.HasPrefix(id, "API") {
+		return id
+	}
+	return "API" + id
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+// goType maps a schema to a Go type expression.
+func goType(s *schema) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if s.Ref != "" {
+		return "*" + modelName(refName(s.Ref))
+	}
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + strings.TrimPrefix(goType(s.Items), "*")
+	case "object":
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// properties flattens allOf compositions into a single property set.
+func (d *document) properties(s *schema, seen map[string]bool) map[string]*schema {
+	props := map[string]*schema{}
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		if seen[name] {
+			return props
+		}
+		seen[name] = true
+		if target, ok := d.Components.Schemas[name]; ok {
+			return d.properties(target, seen)
+		}
+		return props
+	}
+	for _, part := range s.AllOf {
+		for name, prop := range d.properties(part, seen) {
+			props[name] = prop
+		}
+	}
+	for name, prop := range s.Properties {
+		props[name] = prop
+	}
+	return props
+}
+
+// generate renders the models and low-level endpoint methods of the spec as a formatted Go source file.
+func generate(doc *document, pkg string) ([]byte, error) {
+	endpoints, err := doc.endpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeModel(&b, doc, name)
+	}
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		seen[modelName(name)] = true
+	}
+	for _, ep := range endpoints {
+		writeEndpoint(&b, doc, ep, seen)
+	}
+
+	imports := []string{"context", "net/http", "net/url"}
+	if bytes.Contains(b.Bytes(), []byte("time.Time")) {
+		imports = append(imports, "time")
+	}
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by bhgen from the BloodHound OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&file, "package %s\n\nimport (\n", pkg)
+	for _, path := range imports {
+		fmt.Fprintf(&file, "\t%q\n", path)
+	}
+	fmt.Fprintf(&file, ")\n\n")
+	file.Write(b.Bytes())
+
+	source, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not compile: %w", err)
+	}
+	return source, nil
+}
+
+func writeModel(b *bytes.Buffer, doc *document, name string) {
+	s := doc.Components.Schemas[name]
+	typeName := modelName(name)
+	props := doc.properties(s, map[string]bool{name: true})
+	if len(props) == 0 {
+		fmt.Fprintf(b, "// %s is the %s schema.\ntype %s %s\n\n", typeName, name, typeName, strings.TrimPrefix(goType(s), "*"))
+		return
+	}
+
+	fmt.Fprintf(b, "// %s is the %s schema.\n", typeName, name)
+	writeStruct(b, typeName, props)
+}
+
+func writeStruct(b *bytes.Buffer, typeName string, props map[string]*schema) {
+	propNames := make([]string, 0, len(props))
+	for propName := range props {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
+
+	fmt.Fprintf(b, "type %s struct {\n", typeName)
+	fields := map[string]bool{}
+	for _, propName := range propNames {
+		field := identifier(propName)
+		for fields[field] {
+			field += "_"
+		}
+		fields[field] = true
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", field, goType(props[propName]), propName+",omitempty")
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+// responseType returns the Go type an endpoint method decodes the response body into,
+// declaring a struct for inline object schemas, or "" when the endpoint has no JSON body.
+func responseType(b *bytes.Buffer, doc *document, opName string, s *schema, seen map[string]bool) string {
+	if s == nil {
+		return ""
+	}
+	if s.Ref != "" || s.Type == "array" {
+		return goType(s)
+	}
+	props := doc.properties(s, map[string]bool{})
+	if len(props) == 0 {
+		return goType(s)
+	}
+	typeName := "API" + opName + "Response"
+	for seen[typeName] {
+		typeName += "_"
+	}
+	seen[typeName] = true
+	fmt.Fprintf(b, "// %s is the response body of %s.\n", typeName, opName)
+	writeStruct(b, typeName, props)
+	return "*" + typeName
+}
+
+func writeEndpoint(b *bytes.Buffer, doc *document, ep endpoint, seen map[string]bool) {
+	opID := ep.Operation.OperationID
+	if opID == "" {
+		opID = strings.ToLower(ep.Method) + " " + ep.Path
+	}
+	opName := identifier(opID)
+	name := "Raw" + opName
+	for seen[name] {
+		name += "_"
+	}
+	seen[name] = true
+	outType := responseType(b, doc, opName, ep.Response, seen)
+
+	var args, joinArgs []string
+	literal := ""
+	for _, segment := range strings.Split(strings.Trim(ep.Path, "/"), "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			arg := argument(strings.Trim(segment, "{}"))
+			args = append(args, arg+" string")
+			joinArgs = append(joinArgs, fmt.Sprintf("%q", "/"+literal+"/"), arg)
+			literal = ""
+			continue
+		}
+		if literal != "" {
+			literal += "/"
+		}
+		literal += segment
+	}
+	if literal != "" || len(joinArgs) == 0 {
+		joinArgs = append(joinArgs, fmt.Sprintf("%q", "/"+literal))
+	}
+	args = append(args, "query url.Values")
+	body := "nil"
+	if len(ep.Operation.RequestBody) > 0 {
+		args = append(args, "body interface{}")
+		body = "body"
+	}
+
+	fmt.Fprintf(b, "// %s calls %s %s.\n", name, ep.Method, ep.Path)
+	if summary := strings.TrimSpace(ep.Operation.Summary); summary != "" {
+		fmt.Fprintf(b, "//\n// %s\n", strings.ReplaceAll(summary, "\n", "\n// "))
+	}
+	if ep.Operation.Deprecated {
+		fmt.Fprintf(b, "//\n// Deprecated: the endpoint is deprecated by the server.\n")
+	}
+	call := fmt.Sprintf("c.doJSON(ctx, http.Method%s, apiUrl, %s, ", methodConst(ep.Method), body)
+	switch {
+	case outType == "":
+		fmt.Fprintf(b, "func (c *Client) %s(ctx context.Context, %s) error {\n", name, strings.Join(args, ", "))
+	default:
+		fmt.Fprintf(b, "func (c *Client) %s(ctx context.Context, %s) (%s, error) {\n", name, strings.Join(args, ", "), outType)
+	}
+	fmt.Fprintf(b, "\tapiUrl := c.baseURL.JoinPath(%s)\n", strings.Join(joinArgs, ", "))
+	fmt.Fprintf(b, "\tapiUrl.RawQuery = query.Encode()\n")
+	switch {
+	case outType == "":
+		fmt.Fprintf(b, "\treturn %snil)\n", call)
+	case strings.HasPrefix(outType, "*"):
+		fmt.Fprintf(b, "\tout := new(%s)\n", strings.TrimPrefix(outType, "*"))
+		fmt.Fprintf(b, "\tif err := %sout); err != nil {\n\t\treturn nil, err\n\t}\n\treturn out, nil\n", call)
+	default:
+		fmt.Fprintf(b, "\tvar out %s\n", outType)
+		fmt.Fprintf(b, "\terr := %s&out)\n\treturn out, err\n", call)
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+func methodConst(method string) string {
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
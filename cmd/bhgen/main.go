@@ -0,0 +1,74 @@
+// Command bhgen generates low-level client methods and models from the official
+// BloodHound OpenAPI document, and checks the hand-written client against it.
+//
+// With -fetch, the document is first downloaded from -url (the official document by
+// default) into the -spec path. The coverage check exits with a non-zero status when
+// the client calls a path the document does not have, and with -strict also when a
+// document endpoint is missing from the client.
+//
+// Usage:
+//
+//	bhgen [-fetch] -spec openapi.json -out zz_generated_api.go
+//	bhgen [-fetch] -spec openapi.json -coverage [-src .] [-strict]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the BloodHound OpenAPI JSON document")
+	outPath := flag.String("out", "zz_generated_api.go", "file to write the generated code to")
+	pkgName := flag.String("pkg", "bloodhound", "package name of the generated file")
+	coverageMode := flag.Bool("coverage", false, "report endpoint coverage of the hand-written client instead of generating code")
+	srcDir := flag.String("src", ".", "directory holding the hand-written client sources (coverage mode)")
+	strict := flag.Bool("strict", false, "also fail when spec endpoints are missing from the client (coverage mode)")
+	fetch := flag.Bool("fetch", false, "download the OpenAPI document into the -spec path first")
+	specURL := flag.String("url", officialSpecURL, "URL of the OpenAPI document to download with -fetch")
+	flag.Parse()
+
+	if *specPath == "" || strings.HasPrefix(*specPath, "-") {
+		log.Fatal("bhgen: -spec requires the path of the BloodHound OpenAPI document")
+	}
+	if flag.NArg() > 0 {
+		log.Fatalf("bhgen: unexpected arguments %q", flag.Args())
+	}
+
+	if *fetch {
+		if err := fetchSpec(*specURL, *specPath); err != nil {
+			log.Fatalf("bhgen: %v", err)
+		}
+	}
+
+	doc, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatalf("bhgen: %v", err)
+	}
+
+	if *coverageMode {
+		report, err := coverage(doc, *srcDir)
+		if err != nil {
+			log.Fatalf("bhgen: %v", err)
+		}
+		fmt.Print(report.String())
+		if doc.Info.Subset {
+			fmt.Printf("\n%s describes only part of the API; run with -fetch to check against the official document.\n", *specPath)
+		}
+		if report.failed(*strict) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	source, err := generate(doc, *pkgName)
+	if err != nil {
+		log.Fatalf("bhgen: %v", err)
+	}
+	if err := os.WriteFile(*outPath, source, 0o644); err != nil {
+		log.Fatalf("bhgen: %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// httpMethods lists the operation keys of an OpenAPI path item, in output order.
+var httpMethods = []string{"get", "put", "post", "delete", "patch", "head", "options"}
+
+type document struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+		// Subset marks a hand-written document that describes only some endpoints of the API,
+		// so coverage against it says nothing about the hand-written client.
+		Subset bool `json:"x-bhgen-subset"`
+	} `json:"info"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas    map[string]*schema    `json:"schemas"`
+		Parameters map[string]*parameter `json:"parameters"`
+		Responses  map[string]*response  `json:"responses"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*parameter         `json:"parameters"`
+	RequestBody json.RawMessage      `json:"requestBody"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type response struct {
+	Ref     string `json:"$ref"`
+	Content map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"content"`
+}
+
+type parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Items      *schema            `json:"items"`
+	Properties map[string]*schema `json:"properties"`
+	AllOf      []*schema          `json:"allOf"`
+}
+
+// endpoint is a single resolved operation of the spec.
+type endpoint struct {
+	Method     string
+	Path       string
+	Operation  operation
+	PathParams []*parameter
+	// Response is the schema of the success response body, or nil when there is none.
+	Response *schema
+}
+
+// officialSpecURL is the official BloodHound CE OpenAPI document.
+const officialSpecURL = "https://raw.githubusercontent.com/SpecterOps/BloodHound/main/packages/go/openapi/doc/openapi.json"
+
+// fetchSpec downloads the OpenAPI document at specURL and writes it to path. The file is
+// only replaced once the download has parsed as a document with at least one path.
+func fetchSpec(specURL, path string) error {
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Get(specURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch spec: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch spec: %s returned %s", specURL, resp.Status)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to fetch spec: %w", err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("failed to parse fetched spec: %w", err)
+	}
+	if len(doc.Paths) == 0 {
+		return fmt.Errorf("fetched spec from %s has no paths", specURL)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	return nil
+}
+
+func loadSpec(path string) (*document, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	var doc document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	return &doc, nil
+}
+
+// resolveParameter follows a #/components/parameters reference.
+func (d *document) resolveParameter(p *parameter) *parameter {
+	if p.Ref == "" {
+		return p
+	}
+	if resolved, ok := d.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]; ok {
+		return resolved
+	}
+	return p
+}
+
+// resolveResponse follows a #/components/responses reference.
+func (d *document) resolveResponse(r *response) *response {
+	if r.Ref == "" {
+		return r
+	}
+	if resolved, ok := d.Components.Responses[strings.TrimPrefix(r.Ref, "#/components/responses/")]; ok {
+		return resolved
+	}
+	return r
+}
+
+// successSchema returns the JSON schema of the lowest 2xx response of the operation, or
+// nil when it has no JSON body.
+func (d *document) successSchema(op operation) *schema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if content, ok := d.resolveResponse(op.Responses[code]).Content["application/json"]; ok && content.Schema != nil {
+			return content.Schema
+		}
+	}
+	return nil
+}
+
+// endpoints returns every operation of the spec, sorted by path and method.
+func (d *document) endpoints() ([]endpoint, error) {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var endpoints []endpoint
+	for _, path := range paths {
+		item := d.Paths[path]
+
+		var shared []*parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return nil, fmt.Errorf("failed to parse parameters of %s: %w", path, err)
+			}
+		}
+
+		for _, method := range httpMethods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s: %w", strings.ToUpper(method), path, err)
+			}
+
+			ep := endpoint{Method: strings.ToUpper(method), Path: path, Operation: op, Response: d.successSchema(op)}
+			for _, p := range append(append([]*parameter{}, shared...), op.Parameters...) {
+				if p = d.resolveParameter(p); p.In == "path" {
+					ep.PathParams = append(ep.PathParams, p)
+				}
+			}
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
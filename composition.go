@@ -0,0 +1,154 @@
+package bloodhound
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CompositeEdgeKinds are the post-processed edge kinds the edge composition endpoint can
+// explain: edges created from a combination of other edges and nodes.
+var CompositeEdgeKinds = []EdgeKind{
+	EdgeGoldenCert,
+	EdgeADCSESC1, EdgeADCSESC3, EdgeADCSESC4, EdgeADCSESC6a, EdgeADCSESC6b,
+	EdgeADCSESC9a, EdgeADCSESC9b, EdgeADCSESC10a, EdgeADCSESC10b, EdgeADCSESC13,
+	EdgeCoerceAndRelayNTLMToSMB, EdgeCoerceAndRelayNTLMToADCS,
+	EdgeCoerceAndRelayNTLMToLDAP, EdgeCoerceAndRelayNTLMToLDAPS,
+}
+
+// IsComposite reports whether the edge kind is post-processed from other edges and can
+// be expanded with GetEdgeComposition.
+func (k EdgeKind) IsComposite() bool {
+	for _, kind := range CompositeEdgeKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// EdgeComposition explains a composite edge by the nodes and edges it was built from.
+type EdgeComposition struct {
+	// Edge is the composite edge itself.
+	Edge GraphEdge
+	Kind EdgeKind
+	// Nodes are the constituent nodes in order of distance from the composite edge's
+	// source; nodes the source does not reach come last.
+	Nodes []PathNode
+	// Edges are the constituent edges, ordered like their source nodes.
+	Edges []GraphEdge
+	// Paths are the constituent paths from the source to the target of the composite
+	// edge, shortest first. Compositions such as ADCSESC1 have several.
+	Paths []Path
+}
+
+// Graph returns the constituent nodes and edges as an in-memory graph.
+func (e *EdgeComposition) Graph() *Graph {
+	g := NewGraph()
+	for _, node := range e.Nodes {
+		g.AddNode(node.ID, node.GraphNodeProperties)
+	}
+	for _, edge := range e.Edges {
+		g.AddEdge(edge)
+	}
+	return g
+}
+
+// GetEdgeComposition fetches the composition of a composite edge taken from a graph
+// response, e.g. an ADCSESC1 or GoldenCert edge on a path.
+func (c *Client) GetEdgeComposition(edge GraphEdge) (*EdgeComposition, error) {
+	kind := edge.EdgeKind()
+	if !kind.IsComposite() {
+		return nil, fmt.Errorf("edge kind %s is not a composite edge", kind)
+	}
+	response, err := c.GetPathComposition(edge.Source, edge.Target, string(kind))
+	if err != nil {
+		return nil, err
+	}
+	return newEdgeComposition(edge, response.Data), nil
+}
+
+// newEdgeComposition orders the nodes and edges of a composition response.
+func newEdgeComposition(edge GraphEdge, data ShortestPathData) *EdgeComposition {
+	composition := &EdgeComposition{Edge: edge, Kind: edge.EdgeKind()}
+
+	outgoing := map[string][]GraphEdge{}
+	for _, e := range data.Edges {
+		outgoing[e.Source] = append(outgoing[e.Source], e)
+	}
+	for _, edges := range outgoing {
+		sort.SliceStable(edges, func(i, j int) bool { return edges[i].Target < edges[j].Target })
+	}
+
+	depth := map[string]int{}
+	var order []string
+	if _, ok := data.Nodes[edge.Source]; ok {
+		depth[edge.Source] = 0
+		order = append(order, edge.Source)
+	}
+	for i := 0; i < len(order); i++ {
+		for _, e := range outgoing[order[i]] {
+			if _, seen := depth[e.Target]; !seen {
+				depth[e.Target] = depth[order[i]] + 1
+				order = append(order, e.Target)
+			}
+		}
+	}
+	var unreached []string
+	for id := range data.Nodes {
+		if _, seen := depth[id]; !seen {
+			unreached = append(unreached, id)
+		}
+	}
+	sort.Strings(unreached)
+	order = append(order, unreached...)
+
+	for _, id := range order {
+		composition.Nodes = append(composition.Nodes, PathNode{ID: id, GraphNodeProperties: data.Nodes[id]})
+		composition.Edges = append(composition.Edges, outgoing[id]...)
+	}
+	composition.Paths = pathsBetweenIDs(data.Nodes, data.Edges, edge.Source, edge.Target)
+	return composition
+}
+
+// ExpandedPath is a path whose composite edges have been expanded.
+type ExpandedPath struct {
+	Path
+	// Compositions holds the composition of Edges[i] at index i, or nil when the edge is
+	// not composite.
+	Compositions []*EdgeComposition
+}
+
+// Graph returns the path and the constituents of its composite edges as an in-memory graph.
+func (p ExpandedPath) Graph() *Graph {
+	g := GraphFromPaths(p.Path)
+	for _, composition := range p.Compositions {
+		if composition == nil {
+			continue
+		}
+		for _, node := range composition.Nodes {
+			g.AddNode(node.ID, node.GraphNodeProperties)
+		}
+		for _, edge := range composition.Edges {
+			if !g.hasEdge(edge) {
+				g.AddEdge(edge)
+			}
+		}
+	}
+	return g
+}
+
+// ExpandPath fetches the composition of every composite edge on a path.
+func (c *Client) ExpandPath(path Path) (*ExpandedPath, error) {
+	expanded := &ExpandedPath{Path: path, Compositions: make([]*EdgeComposition, len(path.Edges))}
+	for i, edge := range path.Edges {
+		if !edge.EdgeKind().IsComposite() {
+			continue
+		}
+		composition, err := c.GetEdgeComposition(edge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand %s edge at hop %d: %w", edge.EdgeKind(), i, err)
+		}
+		expanded.Compositions[i] = composition
+	}
+	return expanded, nil
+}
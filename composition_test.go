@@ -0,0 +1,42 @@
+package bloodhound
+
+import "testing"
+
+func TestNewEdgeComposition(t *testing.T) {
+	esc1 := GraphEdge{Source: "1", Target: "5", Kind: string(EdgeADCSESC1)}
+	data := ShortestPathData{
+		Nodes: map[string]GraphNodeProperties{
+			"1": {Name: "ALICE@CORP.LOCAL", Kind: "User"},
+			"2": {Name: "ESC1TEMPLATE@CORP.LOCAL", Kind: "CertTemplate"},
+			"3": {Name: "CORP-CA@CORP.LOCAL", Kind: "EnterpriseCA"},
+			"4": {Name: "NTAUTHCERTIFICATES@CORP.LOCAL", Kind: "NTAuthStore"},
+			"5": {Name: "CORP.LOCAL", Kind: "Domain"},
+		},
+		Edges: []GraphEdge{
+			{Source: "4", Target: "5", Kind: "NTAuthStoreFor"},
+			{Source: "3", Target: "4", Kind: "TrustedForNTAuth"},
+			{Source: "2", Target: "3", Kind: "PublishedTo"},
+			{Source: "1", Target: "3", Kind: "Enroll"},
+			{Source: "1", Target: "2", Kind: "Enroll"},
+		},
+	}
+
+	composition := newEdgeComposition(esc1, data)
+	var order string
+	for _, node := range composition.Nodes {
+		order += node.ID
+	}
+	if order != "12345" {
+		t.Errorf("node order = %s, want 12345", order)
+	}
+	if len(composition.Edges) != 5 || composition.Edges[0].Target != "2" || composition.Edges[4].Kind != "NTAuthStoreFor" {
+		t.Errorf("unexpected edge order: %+v", composition.Edges)
+	}
+	if len(composition.Paths) != 2 || composition.Paths[0].Len() != 3 || composition.Paths[1].Len() != 4 {
+		t.Errorf("unexpected constituent paths: %v", composition.Paths)
+	}
+
+	if !EdgeGoldenCert.IsComposite() || EdgeMemberOf.IsComposite() {
+		t.Error("IsComposite misclassified an edge kind")
+	}
+}
@@ -1,29 +1,15 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 )
 
 // GetComputer fetches a single computer by its Object ID (SID).
 func (c *Client) GetComputer(objectID string) (*Computer, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get computer failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -45,8 +31,8 @@ func (c *Client) GetComputer(objectID string) (*Computer, error) {
 			SQLAdminUsers    int      `json:"sqlAdminUsers"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get computer response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
 	}
 
 	computer := response.Data.Props
@@ -72,30 +58,11 @@ func (c *Client) GetComputer(objectID string) (*Computer, error) {
 
 // GetComputerByName fetches a single computer by its name.
 func (c *Client) GetComputerByName(computerName string) (*Computer, error) {
-	searchResponse, err := c.Search(computerName, "Computer", 0)
+	objectID, err := c.resolveObjectID("Computer", computerName)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(searchResponse.Data) == 0 && strings.Contains(computerName, "@") {
-		samAccountName := strings.Split(computerName, "@")[0]
-		searchResponse, err = c.Search(samAccountName, "Computer", 0)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, computerName) || (strings.Contains(computerName, "@") && strings.EqualFold(result.Name, strings.Split(computerName, "@")[0])) {
-			return c.GetComputer(result.ObjectID)
-		}
-	}
-
-	if len(searchResponse.Data) == 1 {
-		return c.GetComputer(searchResponse.Data[0].ObjectID)
-	}
-
-	return nil, fmt.Errorf("computer not found: %s", computerName)
+	return c.GetComputer(objectID)
 }
 
 // GetComputerAdmins fetches the list of principals with admin rights to a given computer.
@@ -107,19 +74,8 @@ func (c *Client) GetComputerAdmins(objectID string, limit int) (EntityAdminsResp
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerSessions fetches the user sessions on a given computer.
@@ -131,91 +87,47 @@ func (c *Client) GetComputerSessions(objectID string, limit int) (SessionsRespon
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerRDPUsers fetches the principals with RDP rights to a given computer.
 func (c *Client) GetComputerRDPUsers(objectID string, limit int) (PrivilegesResponse, error) {
 	var rawResponse PrivilegesResponse
-	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/rdp-rights")
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/rdp-users")
 	params := url.Values{}
 	if limit > 0 {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerDCOMUsers fetches the principals with DCOM rights to a given computer.
 func (c *Client) GetComputerDCOMUsers(objectID string, limit int) (PrivilegesResponse, error) {
 	var rawResponse PrivilegesResponse
-	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/dcom-rights")
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/dcom-users")
 	params := url.Values{}
 	if limit > 0 {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerPSRemoteUsers fetches the principals with PSRemote rights to a given computer.
 func (c *Client) GetComputerPSRemoteUsers(objectID string, limit int) (PrivilegesResponse, error) {
 	var rawResponse PrivilegesResponse
-	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/ps-remote-rights")
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/ps-remote-users")
 	params := url.Values{}
 	if limit > 0 {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerSQLAdmins fetches the principals with SQL admin rights to a given computer.
@@ -227,22 +139,11 @@ func (c *Client) GetComputerSQLAdmins(objectID string, limit int) (PrivilegesRes
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
-// GetComputerConstrainedDelegation fetches the constrained delegation privileges for a given computer.
+// GetComputerConstrainedDelegation fetches the principals a given computer can delegate to.
 func (c *Client) GetComputerConstrainedDelegation(objectID string, limit int) (ConstrainedDelegationsResponse, error) {
 	var rawResponse ConstrainedDelegationsResponse
 	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/constrained-delegation-rights")
@@ -251,19 +152,8 @@ func (c *Client) GetComputerConstrainedDelegation(objectID string, limit int) (C
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerControllers fetches the controllers of a given computer.
@@ -275,19 +165,8 @@ func (c *Client) GetComputerControllers(objectID string, limit int) (Controllers
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerMemberships fetches the group memberships for a given computer.
@@ -299,19 +178,8 @@ func (c *Client) GetComputerMemberships(objectID string, limit int) (GroupMember
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetComputerControllables fetches the controllables of a given computer.
@@ -323,17 +191,71 @@ func (c *Client) GetComputerControllables(objectID string, limit int) (Controlla
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetComputerAdminRights fetches the entities a given computer has admin rights to.
+func (c *Client) GetComputerAdminRights(objectID string, limit int) (EntityAdminsResponse, error) {
+	var rawResponse EntityAdminsResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/admin-rights")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetComputerRDPRights fetches the computers a given computer can RDP into.
+func (c *Client) GetComputerRDPRights(objectID string, limit int) (PrivilegesResponse, error) {
+	var rawResponse PrivilegesResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/rdp-rights")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetComputerDCOMRights fetches the computers a given computer has DCOM rights to.
+func (c *Client) GetComputerDCOMRights(objectID string, limit int) (PrivilegesResponse, error) {
+	var rawResponse PrivilegesResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/dcom-rights")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
+	}
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetComputerPSRemoteRights fetches the computers a given computer has PSRemote rights to.
+func (c *Client) GetComputerPSRemoteRights(objectID string, limit int) (PrivilegesResponse, error) {
+	var rawResponse PrivilegesResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/ps-remote-rights")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
-	return rawResponse, nil
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetComputerConstrainedUsers fetches the principals allowed to delegate to a given computer.
+func (c *Client) GetComputerConstrainedUsers(objectID string, limit int) (ConstrainedDelegationsResponse, error) {
+	var rawResponse ConstrainedDelegationsResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/computers/", objectID, "/constrained-users")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
+	}
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
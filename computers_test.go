@@ -0,0 +1,50 @@
+package bloodhound
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestComputerRelationshipRoutes(t *testing.T) {
+	var path string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/computers/", func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		writeTestJSON(w, http.StatusOK, `{"count": 0, "data": []}`)
+	})
+	client := newTestClient(t, mux)
+
+	// The *Users methods list the principals with a right to the computer and the *Rights
+	// methods the computers the computer has the right to.
+	routes := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"GetComputerAdmins", func() error { _, err := client.GetComputerAdmins("S-1-5-21-1-1000", 0); return err }, "admin-users"},
+		{"GetComputerAdminRights", func() error { _, err := client.GetComputerAdminRights("S-1-5-21-1-1000", 0); return err }, "admin-rights"},
+		{"GetComputerRDPUsers", func() error { _, err := client.GetComputerRDPUsers("S-1-5-21-1-1000", 0); return err }, "rdp-users"},
+		{"GetComputerRDPRights", func() error { _, err := client.GetComputerRDPRights("S-1-5-21-1-1000", 0); return err }, "rdp-rights"},
+		{"GetComputerDCOMUsers", func() error { _, err := client.GetComputerDCOMUsers("S-1-5-21-1-1000", 0); return err }, "dcom-users"},
+		{"GetComputerDCOMRights", func() error { _, err := client.GetComputerDCOMRights("S-1-5-21-1-1000", 0); return err }, "dcom-rights"},
+		{"GetComputerPSRemoteUsers", func() error { _, err := client.GetComputerPSRemoteUsers("S-1-5-21-1-1000", 0); return err }, "ps-remote-users"},
+		{"GetComputerPSRemoteRights", func() error { _, err := client.GetComputerPSRemoteRights("S-1-5-21-1-1000", 0); return err }, "ps-remote-rights"},
+		{"GetComputerSQLAdmins", func() error { _, err := client.GetComputerSQLAdmins("S-1-5-21-1-1000", 0); return err }, "sql-admins"},
+		{"GetComputerConstrainedDelegation", func() error { _, err := client.GetComputerConstrainedDelegation("S-1-5-21-1-1000", 0); return err }, "constrained-delegation-rights"},
+		{"GetComputerConstrainedUsers", func() error { _, err := client.GetComputerConstrainedUsers("S-1-5-21-1-1000", 0); return err }, "constrained-users"},
+		{"GetComputerSessions", func() error { _, err := client.GetComputerSessions("S-1-5-21-1-1000", 0); return err }, "sessions"},
+		{"GetComputerControllers", func() error { _, err := client.GetComputerControllers("S-1-5-21-1-1000", 0); return err }, "controllers"},
+		{"GetComputerControllables", func() error { _, err := client.GetComputerControllables("S-1-5-21-1-1000", 0); return err }, "controllables"},
+		{"GetComputerMemberships", func() error { _, err := client.GetComputerMemberships("S-1-5-21-1-1000", 0); return err }, "group-membership"},
+	}
+	for _, route := range routes {
+		path = ""
+		if err := route.call(); err != nil {
+			t.Errorf("%s returned an error: %v", route.name, err)
+			continue
+		}
+		if want := "/api/v2/computers/S-1-5-21-1-1000/" + route.want; path != want {
+			t.Errorf("%s requested %q, want %q", route.name, path, want)
+		}
+	}
+}
@@ -1,37 +1,23 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 )
 
 // GetContainer fetches a single container by its Object ID (GUID).
 func (c *Client) GetContainer(objectID string) (*Container, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/containers/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get container failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
 			Props Container `json:"props"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get container response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
 	}
 	container := response.Data.Props
 	container.ObjectType = "Container"
@@ -40,18 +26,11 @@ func (c *Client) GetContainer(objectID string) (*Container, error) {
 
 // GetContainerByName fetches a single container by its name.
 func (c *Client) GetContainerByName(containerName string) (*Container, error) {
-	searchResponse, err := c.Search(containerName, "Container", 0)
+	objectID, err := c.resolveObjectID("Container", containerName)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, containerName) {
-			return c.GetContainer(result.ObjectID)
-		}
-	}
-
-	return nil, fmt.Errorf("container not found: %s", containerName)
+	return c.GetContainer(objectID)
 }
 
 // GetContainerUsers fetches the users in a given container.
@@ -63,19 +42,8 @@ func (c *Client) GetContainerUsers(objectID string, limit int) (UsersResponse, e
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetContainerComputers fetches the computers in a given container.
@@ -87,19 +55,8 @@ func (c *Client) GetContainerComputers(objectID string, limit int) (ComputersRes
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetContainerGroups fetches the groups in a given container.
@@ -111,19 +68,8 @@ func (c *Client) GetContainerGroups(objectID string, limit int) (GroupsResponse,
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetContainerControllers fetches the controllers of a given container.
@@ -135,17 +81,11 @@ func (c *Client) GetContainerControllers(objectID string, limit int) (Controller
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetContainerGPOInheritance fetches the GPOs that apply to a given container, including inherited and enforced links.
+func (c *Client) GetContainerGPOInheritance(objectID string) ([]InheritedGPO, error) {
+	return c.GetGPOInheritance(objectID)
 }
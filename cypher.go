@@ -1,10 +1,13 @@
 package bloodhound
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 // CypherQuery represents a Cypher query.
@@ -30,16 +33,7 @@ func (c *Client) ListSavedQueries() ([]SavedQuery, error) {
 		Data []SavedQuery `json:"data"`
 	}
 	apiUrl := c.baseURL.JoinPath("/api/v2/saved-queries")
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
 		return nil, err
 	}
 	return response.Data, nil
@@ -51,36 +45,13 @@ var ErrDuplicateQueryName = fmt.Errorf("duplicate name for saved query")
 func (c *Client) CreateSavedQuery(name, query, description string, public bool) (*SavedQuery, error) {
 	var savedQuery SavedQuery
 	apiUrl := c.baseURL.JoinPath("/api/v2/saved-queries")
-	body, err := json.Marshal(SavedQuery{Name: name, Query: query, Description: description, Public: public})
-	if err != nil {
-		return nil, err
-	}
-	req, err := c.newAuthenticatedRequest(http.MethodPost, apiUrl.String(), bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusBadRequest {
-		var errorResponse ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err == nil {
-			for _, e := range errorResponse.Errors {
-				if e.Message == "duplicate name for saved query: please choose a different name" {
-					return nil, ErrDuplicateQueryName
-				}
-			}
+	body := SavedQuery{Name: name, Query: query, Description: description, Public: public}
+	if err := c.doJSON(context.Background(), http.MethodPost, apiUrl, body, &savedQuery); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest &&
+			apiErr.Message == "duplicate name for saved query: please choose a different name" {
+			return nil, ErrDuplicateQueryName
 		}
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to create saved query with status code: %d", resp.StatusCode)
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&savedQuery); err != nil {
 		return nil, err
 	}
 	return &savedQuery, nil
@@ -89,93 +60,90 @@ func (c *Client) CreateSavedQuery(name, query, description string, public bool)
 // UpdateSavedQuery updates a saved Cypher query.
 func (c *Client) UpdateSavedQuery(id int, name, query, description string) error {
 	apiUrl := c.baseURL.JoinPath(fmt.Sprintf("/api/v2/saved-queries/%d", id))
-	body, err := json.Marshal(SavedQuery{Name: name, Query: query, Description: description})
-	if err != nil {
-		return err
-	}
-	req, err := c.newAuthenticatedRequest(http.MethodPut, apiUrl.String(), bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
-	_, err = c.do(req, nil)
-	return err
+	body := SavedQuery{Name: name, Query: query, Description: description}
+	return c.doJSON(context.Background(), http.MethodPut, apiUrl, body, nil)
 }
 
 // DeleteSavedQuery deletes a saved Cypher query.
 func (c *Client) DeleteSavedQuery(id int) error {
 	apiUrl := c.baseURL.JoinPath(fmt.Sprintf("/api/v2/saved-queries/%d", id))
-	req, err := c.newAuthenticatedRequest(http.MethodDelete, apiUrl.String(), nil)
-	if err != nil {
-		return err
-	}
-	_, err = c.do(req, nil)
-	return err
+	return c.doJSON(context.Background(), http.MethodDelete, apiUrl, nil, nil)
 }
 
 // ShareSavedQuery shares a saved Cypher query.
 func (c *Client) ShareSavedQuery(id int, public bool, userSIDs []string) error {
 	apiUrl := c.baseURL.JoinPath(fmt.Sprintf("/api/v2/saved-queries/%d/shares", id))
-	body, err := json.Marshal(map[string]interface{}{"public": public, "user_sids": userSIDs})
-	if err != nil {
-		return err
-	}
-	req, err := c.newAuthenticatedRequest(http.MethodPost, apiUrl.String(), bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
-	_, err = c.do(req, nil)
-	return err
+	body := map[string]interface{}{"public": public, "user_sids": userSIDs}
+	return c.doJSON(context.Background(), http.MethodPost, apiUrl, body, nil)
 }
 
 // RevokeSavedQuery revokes a saved Cypher query.
 func (c *Client) RevokeSavedQuery(id int, userSIDs []string) error {
 	apiUrl := c.baseURL.JoinPath(fmt.Sprintf("/api/v2/saved-queries/%d/shares", id))
-	body, err := json.Marshal(map[string]interface{}{"user_sids": userSIDs})
-	if err != nil {
-		return err
-	}
-	req, err := c.newAuthenticatedRequest(http.MethodDelete, apiUrl.String(), bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
-	_, err = c.do(req, nil)
-	return err
+	body := map[string]interface{}{"user_sids": userSIDs}
+	return c.doJSON(context.Background(), http.MethodDelete, apiUrl, body, nil)
 }
 
-// RunCypherQuery runs a Cypher query.
+// RunCypherQuery runs a Cypher query. A query that matches nothing returns an empty
+// JSON object rather than an error.
 func (c *Client) RunCypherQuery(query string) (json.RawMessage, error) {
+	return c.runCypherQuery(context.Background(), query)
+}
+
+// runCypherQuery runs a Cypher query bound to ctx. The server answers a query without
+// results with a 404, which is reported as an empty result.
+func (c *Client) runCypherQuery(ctx context.Context, query string) (json.RawMessage, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/graphs/cypher")
-	body, err := json.Marshal(CypherQuery{Query: query, IncludeProperties: true})
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err := c.doJSON(ctx, http.MethodPost, apiUrl, CypherQuery{Query: query, IncludeProperties: true}, &response)
+	if errors.Is(err, ErrNotFound) {
+		return json.RawMessage(`{}`), nil
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("cypher query failed: %w", err)
+	}
+	if len(response.Data) == 0 {
+		return json.RawMessage(`{}`), nil
 	}
-	req, err := c.newAuthenticatedRequest(http.MethodPost, apiUrl.String(), bytes.NewBuffer(body))
+	return response.Data, nil
+}
+
+// RunCypherGraph runs a Cypher query and decodes the graph-shaped result. A query that
+// matches nothing returns an empty graph.
+func (c *Client) RunCypherGraph(ctx context.Context, query string) (*CypherResponseData, error) {
+	raw, err := c.runCypherQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.do(req, nil)
+	var data CypherResponseData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode cypher graph response: %w", err)
+	}
+	return &data, nil
+}
+
+// relatedNodes runs a Cypher query anchored on objectID and returns every other node in
+// the result, sorted by name. It backs the relationships the API has no REST endpoint for.
+// A relationship without nodes returns an empty slice.
+func (c *Client) relatedNodes(ctx context.Context, query, objectID string) ([]GraphNodeProperties, error) {
+	graph, err := c.RunCypherGraph(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errorResponse ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err == nil {
-			return nil, fmt.Errorf("cypher query failed: %s", errorResponse.Errors[0].Message)
+	nodes := []GraphNodeProperties{}
+	for _, node := range graph.Nodes {
+		if !strings.EqualFold(node.ObjectID, objectID) {
+			nodes = append(nodes, node)
 		}
-		return nil, fmt.Errorf("cypher query failed with status code: %d", resp.StatusCode)
-	}
-
-	if resp.ContentLength == 0 {
-		return []byte(`{"data": {}}`), nil
 	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
 
-	var response struct {
-		Data json.RawMessage `json:"data"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode cypher response: %w", err)
-	}
-	return response.Data, nil
+// cypherString quotes a value for safe inclusion in a Cypher query as a string literal.
+func cypherString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
 }
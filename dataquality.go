@@ -17,9 +17,12 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"time"
 )
 
@@ -74,48 +77,227 @@ type AzureDataQualityStat struct {
 	UpdatedAt            time.Time `json:"updated_at"`
 }
 
-func (s *Client) GetADDataQualityStats(domainID string) (ADDataQualityStat, error) {
-	var stats ADDataQualityStat
-	url := s.baseURL.JoinPath("api/v2/ad-domains", domainID, "data-quality-stats")
+// GetADDataQualityStats fetches the full data quality history of an AD domain, oldest first.
+// When the domain has no statistics the error wraps ErrNotFound.
+func (s *Client) GetADDataQualityStats(domainID string) ([]ADDataQualityStat, error) {
+	stats, err := listAll(listPageSize, func(skip, limit int) ([]ADDataQualityStat, int, error) {
+		page, err := s.ListADDataQualityStats(domainID, DataQualityOptions{SortBy: "created_at", Skip: skip, Limit: limit})
+		return page.Data, page.Count, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("no AD data quality stats found for domain %s: %w", domainID, ErrNotFound)
+	}
+	return stats, nil
+}
 
-	if req, err := s.newAuthenticatedRequest(http.MethodGet, url.String(), nil); err != nil {
-		return stats, err
-	} else if resp, err := s.do(req, nil); err != nil {
-		return stats, err
-	} else {
-		defer resp.Body.Close()
-		var response struct {
-			Data []ADDataQualityStat `json:"data"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-			return stats, err
-		}
-		if len(response.Data) > 0 {
-			return response.Data[0], nil
-		}
-		return stats, fmt.Errorf("no AD data quality stats found for domain %s", domainID)
+// GetAzureDataQualityStats fetches the full data quality history of an Azure tenant, oldest first.
+// When the tenant has no statistics the error wraps ErrNotFound.
+func (s *Client) GetAzureDataQualityStats(tenantID string) ([]AzureDataQualityStat, error) {
+	stats, err := listAll(listPageSize, func(skip, limit int) ([]AzureDataQualityStat, int, error) {
+		page, err := s.ListAzureDataQualityStats(tenantID, DataQualityOptions{SortBy: "created_at", Skip: skip, Limit: limit})
+		return page.Data, page.Count, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("no Azure data quality stats found for tenant %s: %w", tenantID, ErrNotFound)
+	}
+	return stats, nil
+}
+
+// DataQualityOptions filters and pages data quality history queries.
+// Zero values leave the corresponding filter unset.
+type DataQualityOptions struct {
+	Start  time.Time
+	End    time.Time
+	SortBy string // e.g. "created_at" or "-created_at" for descending
+	Skip   int
+	Limit  int
+}
+
+func (o DataQualityOptions) values() url.Values {
+	params := url.Values{}
+	if !o.Start.IsZero() {
+		params.Add("start", o.Start.UTC().Format(time.RFC3339))
+	}
+	if !o.End.IsZero() {
+		params.Add("end", o.End.UTC().Format(time.RFC3339))
 	}
+	if o.SortBy != "" {
+		params.Add("sort_by", o.SortBy)
+	}
+	if o.Skip > 0 {
+		params.Add("skip", strconv.Itoa(o.Skip))
+	}
+	if o.Limit > 0 {
+		params.Add("limit", strconv.Itoa(o.Limit))
+	}
+	return params
+}
+
+// ADDataQualityHistory is a page of data quality statistics for an AD domain.
+type ADDataQualityHistory struct {
+	Count int                 `json:"count"`
+	Limit int                 `json:"limit"`
+	Skip  int                 `json:"skip"`
+	Start time.Time           `json:"start"`
+	End   time.Time           `json:"end"`
+	Data  []ADDataQualityStat `json:"data"`
+}
+
+// AzureDataQualityHistory is a page of data quality statistics for an Azure tenant.
+type AzureDataQualityHistory struct {
+	Count int                    `json:"count"`
+	Limit int                    `json:"limit"`
+	Skip  int                    `json:"skip"`
+	Start time.Time              `json:"start"`
+	End   time.Time              `json:"end"`
+	Data  []AzureDataQualityStat `json:"data"`
+}
+
+// ADPlatformDataQualityStat aggregates data quality across every collected AD domain.
+type ADPlatformDataQualityStat struct {
+	ADDataQualityStat
+	Domains int `json:"domains"`
+}
+
+// AzurePlatformDataQualityStat aggregates data quality across every collected Azure tenant.
+type AzurePlatformDataQualityStat struct {
+	AzureDataQualityStat
+	Tenants int `json:"tenants"`
+}
+
+// ListADDataQualityStats fetches the data quality history of an AD domain.
+func (s *Client) ListADDataQualityStats(domainID string, opts DataQualityOptions) (ADDataQualityHistory, error) {
+	var history ADDataQualityHistory
+	url := s.baseURL.JoinPath("api/v2/ad-domains", domainID, "data-quality-stats")
+	url.RawQuery = opts.values().Encode()
+	err := s.doJSON(context.Background(), http.MethodGet, url, nil, &history)
+	return history, err
 }
 
-func (s *Client) GetAzureDataQualityStats(tenantID string) (AzureDataQualityStat, error) {
-	var stats AzureDataQualityStat
+// ListAzureDataQualityStats fetches the data quality history of an Azure tenant.
+func (s *Client) ListAzureDataQualityStats(tenantID string, opts DataQualityOptions) (AzureDataQualityHistory, error) {
+	var history AzureDataQualityHistory
 	url := s.baseURL.JoinPath("api/v2/azure-tenants", tenantID, "data-quality-stats")
+	url.RawQuery = opts.values().Encode()
+	err := s.doJSON(context.Background(), http.MethodGet, url, nil, &history)
+	return history, err
+}
 
-	if req, err := s.newAuthenticatedRequest(http.MethodGet, url.String(), nil); err != nil {
-		return stats, err
-	} else if resp, err := s.do(req, nil); err != nil {
-		return stats, err
-	} else {
-		defer resp.Body.Close()
-		var response struct {
-			Data []AzureDataQualityStat `json:"data"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-			return stats, err
+// ListADPlatformDataQualityStats fetches the data quality history aggregated across all AD domains.
+func (s *Client) ListADPlatformDataQualityStats(opts DataQualityOptions) ([]ADPlatformDataQualityStat, error) {
+	var response struct {
+		Data []ADPlatformDataQualityStat `json:"data"`
+	}
+	url := s.baseURL.JoinPath("api/v2/platform/ad/data-quality-stats")
+	url.RawQuery = opts.values().Encode()
+	err := s.doJSON(context.Background(), http.MethodGet, url, nil, &response)
+	return response.Data, err
+}
+
+// ListAzurePlatformDataQualityStats fetches the data quality history aggregated across all Azure tenants.
+func (s *Client) ListAzurePlatformDataQualityStats(opts DataQualityOptions) ([]AzurePlatformDataQualityStat, error) {
+	var response struct {
+		Data []AzurePlatformDataQualityStat `json:"data"`
+	}
+	url := s.baseURL.JoinPath("api/v2/platform/azure/data-quality-stats")
+	url.RawQuery = opts.values().Encode()
+	err := s.doJSON(context.Background(), http.MethodGet, url, nil, &response)
+	return response.Data, err
+}
+
+// DataQualityDelta describes the change between two consecutive AD collection runs.
+type DataQualityDelta struct {
+	From                        ADDataQualityStat
+	To                          ADDataQualityStat
+	SessionCompletenessDelta    float64
+	LocalGroupCompletenessDelta float64
+	RelationshipsDelta          int
+}
+
+// ADDataQualityDeltas returns the deltas between consecutive runs, oldest first.
+func ADDataQualityDeltas(stats []ADDataQualityStat) []DataQualityDelta {
+	ordered := append([]ADDataQualityStat(nil), stats...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].CreatedAt.Before(ordered[j].CreatedAt) })
+
+	var deltas []DataQualityDelta
+	for i := 1; i < len(ordered); i++ {
+		from, to := ordered[i-1], ordered[i]
+		deltas = append(deltas, DataQualityDelta{
+			From:                        from,
+			To:                          to,
+			SessionCompletenessDelta:    to.SessionCompleteness - from.SessionCompleteness,
+			LocalGroupCompletenessDelta: to.LocalGroupCompleteness - from.LocalGroupCompleteness,
+			RelationshipsDelta:          to.Relationships - from.Relationships,
+		})
+	}
+	return deltas
+}
+
+// DegradedCollections returns the deltas where session or local group completeness
+// dropped by more than the given threshold (e.g. 0.1 for ten percentage points).
+func DegradedCollections(stats []ADDataQualityStat, threshold float64) []DataQualityDelta {
+	var degraded []DataQualityDelta
+	for _, delta := range ADDataQualityDeltas(stats) {
+		if -delta.SessionCompletenessDelta > threshold || -delta.LocalGroupCompletenessDelta > threshold {
+			degraded = append(degraded, delta)
 		}
-		if len(response.Data) > 0 {
-			return response.Data[0], nil
+	}
+	return degraded
+}
+
+// AzureDataQualityDelta describes the change between two consecutive Azure collection runs.
+type AzureDataQualityDelta struct {
+	From               AzureDataQualityStat
+	To                 AzureDataQualityStat
+	ObjectsDelta       int
+	RelationshipsDelta int
+}
+
+// Objects returns the total number of objects collected in the run.
+func (s AzureDataQualityStat) Objects() int {
+	return s.Users + s.Groups + s.Apps + s.ServicePrincipals + s.Devices + s.ManagementGroups +
+		s.Subscriptions + s.ResourceGroups + s.VMs + s.KeyVaults + s.AutomationAccounts +
+		s.ContainerRegistries + s.FunctionApps + s.LogicApps + s.ManagedClusters + s.VMScaleSets + s.WebApps
+}
+
+// AzureDataQualityDeltas returns the deltas between consecutive runs, oldest first.
+func AzureDataQualityDeltas(stats []AzureDataQualityStat) []AzureDataQualityDelta {
+	ordered := append([]AzureDataQualityStat(nil), stats...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].CreatedAt.Before(ordered[j].CreatedAt) })
+
+	var deltas []AzureDataQualityDelta
+	for i := 1; i < len(ordered); i++ {
+		from, to := ordered[i-1], ordered[i]
+		deltas = append(deltas, AzureDataQualityDelta{
+			From:               from,
+			To:                 to,
+			ObjectsDelta:       to.Objects() - from.Objects(),
+			RelationshipsDelta: to.Relationships - from.Relationships,
+		})
+	}
+	return deltas
+}
+
+// DegradedAzureCollections returns the deltas where the number of objects or relationships
+// dropped by more than the given fraction of the previous run (e.g. 0.1 for ten percent).
+// Azure statistics have no completeness measure, so a shrinking collection is the signal.
+func DegradedAzureCollections(stats []AzureDataQualityStat, threshold float64) []AzureDataQualityDelta {
+	var degraded []AzureDataQualityDelta
+	for _, delta := range AzureDataQualityDeltas(stats) {
+		if dropped(delta.ObjectsDelta, delta.From.Objects(), threshold) || dropped(delta.RelationshipsDelta, delta.From.Relationships, threshold) {
+			degraded = append(degraded, delta)
 		}
-		return stats, fmt.Errorf("no Azure data quality stats found for tenant %s", tenantID)
 	}
-}
\ No newline at end of file
+	return degraded
+}
+
+// dropped reports whether delta is a decrease of more than threshold relative to previous.
+func dropped(delta, previous int, threshold float64) bool {
+	return previous > 0 && float64(-delta)/float64(previous) > threshold
+}
@@ -0,0 +1,146 @@
+package bloodhound
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetADDataQualityStats(t *testing.T) {
+	const total = 1203
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/api/v2/ad-domains/S-1-5-21-2/data-quality-stats" {
+			writeTestJSON(w, http.StatusOK, `{"count": 0, "data": []}`)
+			return
+		}
+		if r.URL.Query().Get("sort_by") != "created_at" {
+			t.Errorf("unexpected sort_by: %q", r.URL.Query().Get("sort_by"))
+		}
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		body := fmt.Sprintf(`{"count": %d, "skip": %d, "limit": %d, "data": [`, total, skip, limit)
+		for i := skip; i < skip+limit && i < total; i++ {
+			if i > skip {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"id": %d, "domain_sid": "S-1-5-21-1"}`, i)
+		}
+		writeTestJSON(w, http.StatusOK, body+"]}")
+	}))
+
+	stats, err := client.GetADDataQualityStats("S-1-5-21-1")
+	if err != nil {
+		t.Fatalf("GetADDataQualityStats returned an error: %v", err)
+	}
+	if len(stats) != total || stats[0].ID != 0 || stats[total-1].ID != total-1 || requests != 3 {
+		t.Errorf("got %d stats in %d requests, want %d in 3", len(stats), requests, total)
+	}
+
+	if _, err := client.GetADDataQualityStats("S-1-5-21-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a domain without stats, got %v", err)
+	}
+}
+
+func TestGetAzureDataQualityStats(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/azure-tenants/T1/data-quality-stats" {
+			http.NotFound(w, r)
+			return
+		}
+		writeTestJSON(w, http.StatusOK, `{"count": 2, "data": [{"id": 1, "tenantid": "T1"}, {"id": 2, "tenantid": "T1"}]}`)
+	}))
+
+	stats, err := client.GetAzureDataQualityStats("T1")
+	if err != nil || len(stats) != 2 || stats[1].ID != 2 {
+		t.Errorf("GetAzureDataQualityStats = %+v, %v, want both runs", stats, err)
+	}
+	if _, err := client.GetAzureDataQualityStats("T2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown tenant, got %v", err)
+	}
+}
+
+func TestADDataQualityDeltas(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	stats := []ADDataQualityStat{
+		{ID: 3, CreatedAt: day(3), SessionCompleteness: 0.5, LocalGroupCompleteness: 0.9, Relationships: 900},
+		{ID: 1, CreatedAt: day(1), SessionCompleteness: 0.8, LocalGroupCompleteness: 0.9, Relationships: 1000},
+		{ID: 2, CreatedAt: day(2), SessionCompleteness: 0.75, LocalGroupCompleteness: 0.95, Relationships: 1100},
+	}
+
+	deltas := ADDataQualityDeltas(stats)
+	if len(deltas) != 2 || deltas[0].From.ID != 1 || deltas[0].To.ID != 2 || deltas[1].From.ID != 2 || deltas[1].To.ID != 3 {
+		t.Fatalf("deltas are not ordered by run: %+v", deltas)
+	}
+	if deltas[1].RelationshipsDelta != -200 || !approx(deltas[1].SessionCompletenessDelta, -0.25) || !approx(deltas[1].LocalGroupCompletenessDelta, -0.05) {
+		t.Errorf("unexpected delta: %+v", deltas[1])
+	}
+
+	tests := []struct {
+		threshold float64
+		want      []int
+	}{
+		{0.01, []int{2, 3}},
+		{0.1, []int{3}},
+		{0.3, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, delta := range DegradedCollections(stats, tt.threshold) {
+			got = append(got, delta.To.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("DegradedCollections(%v) = runs %v, want %v", tt.threshold, got, tt.want)
+		}
+	}
+
+	if ADDataQualityDeltas(stats[:1]) != nil || DegradedCollections(nil, 0) != nil {
+		t.Error("expected no deltas for fewer than two runs")
+	}
+}
+
+func TestAzureDataQualityDeltas(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	stats := []AzureDataQualityStat{
+		{ID: 2, CreatedAt: day(2), Users: 95, Groups: 10, VMs: 5, Relationships: 500},
+		{ID: 1, CreatedAt: day(1), Users: 100, Groups: 10, VMs: 5, Relationships: 1000},
+		{ID: 3, CreatedAt: day(3), Users: 50, Groups: 10, VMs: 5, Relationships: 500},
+	}
+	if got := stats[1].Objects(); got != 115 {
+		t.Errorf("Objects() = %d, want 115", got)
+	}
+
+	deltas := AzureDataQualityDeltas(stats)
+	if len(deltas) != 2 || deltas[0].From.ID != 1 || deltas[1].To.ID != 3 {
+		t.Fatalf("deltas are not ordered by run: %+v", deltas)
+	}
+	if deltas[0].ObjectsDelta != -5 || deltas[0].RelationshipsDelta != -500 || deltas[1].ObjectsDelta != -45 || deltas[1].RelationshipsDelta != 0 {
+		t.Errorf("unexpected deltas: %+v", deltas)
+	}
+
+	tests := []struct {
+		threshold float64
+		want      []int
+	}{
+		{0.01, []int{2, 3}},
+		{0.45, []int{2}},
+		{0.5, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, delta := range DegradedAzureCollections(stats, tt.threshold) {
+			got = append(got, delta.To.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("DegradedAzureCollections(%v) = runs %v, want %v", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func approx(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
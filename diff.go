@@ -0,0 +1,348 @@
+package bloodhound
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DefaultDiffIgnoredProperties are properties that change on every collection and are
+// not compared by DiffGraphs unless DiffOptions.Ignore is set.
+var DefaultDiffIgnoredProperties = []string{"lastseen", "lastcollected"}
+
+// Node fields compared by DiffGraphs in addition to the properties. Changes to them are
+// reported under these names, which cannot clash with property names, and can be ignored
+// through DiffOptions.Ignore.
+const (
+	DiffFieldName  = "@name"
+	DiffFieldKind  = "@kind"
+	DiffFieldKinds = "@kinds"
+)
+
+// DiffOptions tunes DiffGraphs.
+type DiffOptions struct {
+	// Properties restricts the compared properties, e.g. admincount, enabled and
+	// pwdlastset. Empty compares every property. The node fields are always compared.
+	Properties []string
+	// Ignore lists properties that are never compared. Nil uses DefaultDiffIgnoredProperties.
+	Ignore []string
+}
+
+// DiffNode identifies a node in a diff.
+type DiffNode struct {
+	ObjectID string `json:"objectid"`
+	Name     string `json:"name,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+}
+
+// DiffEdge identifies an edge in a diff by its kind and endpoints.
+type DiffEdge struct {
+	Kind  EdgeKind `json:"kind"`
+	Start DiffNode `json:"start"`
+	End   DiffNode `json:"end"`
+}
+
+// PropertyChange is a property whose value differs between two graphs. Before or After
+// is nil when the property was added or removed.
+type PropertyChange struct {
+	Property string      `json:"property"`
+	Before   interface{} `json:"before"`
+	After    interface{} `json:"after"`
+}
+
+// NodeChange is a node present in both graphs whose fields or properties differ.
+type NodeChange struct {
+	DiffNode
+	Changes []PropertyChange `json:"changes"`
+}
+
+// DiffGroup holds the changes for one kind in one domain. Nodes are grouped by node kind
+// and edges by edge kind, both under the domain of the node (or the edge's start node).
+type DiffGroup struct {
+	Kind         string       `json:"kind"`
+	Domain       string       `json:"domain"`
+	AddedNodes   []DiffNode   `json:"added_nodes,omitempty"`
+	RemovedNodes []DiffNode   `json:"removed_nodes,omitempty"`
+	ChangedNodes []NodeChange `json:"changed_nodes,omitempty"`
+	AddedEdges   []DiffEdge   `json:"added_edges,omitempty"`
+	RemovedEdges []DiffEdge   `json:"removed_edges,omitempty"`
+}
+
+// DiffSummary counts the changes of a diff.
+type DiffSummary struct {
+	AddedNodes   int `json:"added_nodes"`
+	RemovedNodes int `json:"removed_nodes"`
+	ChangedNodes int `json:"changed_nodes"`
+	AddedEdges   int `json:"added_edges"`
+	RemovedEdges int `json:"removed_edges"`
+}
+
+// GraphDiff is the changeset between two graphs, grouped by kind and domain.
+type GraphDiff struct {
+	From    SnapshotHeader `json:"from"`
+	To      SnapshotHeader `json:"to"`
+	Summary DiffSummary    `json:"summary"`
+	Groups  []DiffGroup    `json:"groups"`
+}
+
+// IsEmpty reports whether the graphs are identical.
+func (d *GraphDiff) IsEmpty() bool {
+	return d.Summary == DiffSummary{}
+}
+
+// DiffSnapshots compares two snapshots, e.g. taken before and after a remediation sprint
+// or around a file upload job.
+func DiffSnapshots(from, to *Snapshot, opts DiffOptions) *GraphDiff {
+	diff := DiffGraphs(from.Graph, to.Graph, opts)
+	diff.From, diff.To = from.Header, to.Header
+	return diff
+}
+
+// DiffGraphs compares two graphs. Nodes are matched by Object ID and edges by kind and
+// endpoint Object IDs, so graphs taken from different instances can be compared; nodes
+// and edges without Object IDs are ignored.
+func DiffGraphs(from, to *Graph, opts DiffOptions) *GraphDiff {
+	ignore := opts.Ignore
+	if ignore == nil {
+		ignore = DefaultDiffIgnoredProperties
+	}
+	ignored := map[string]bool{}
+	for _, property := range ignore {
+		ignored[property] = true
+	}
+
+	diff := &GraphDiff{Groups: []DiffGroup{}}
+	groups := map[[2]string]*DiffGroup{}
+	group := func(kind, domain string) *DiffGroup {
+		key := [2]string{kind, domain}
+		if groups[key] == nil {
+			groups[key] = &DiffGroup{Kind: kind, Domain: domain}
+		}
+		return groups[key]
+	}
+
+	fromNodes, toNodes := diffNodesByObjectID(from), diffNodesByObjectID(to)
+	for objectID, after := range toNodes {
+		node := diffNodeOf(objectID, after)
+		before, ok := fromNodes[objectID]
+		if !ok {
+			g := group(node.Kind, node.Domain)
+			g.AddedNodes = append(g.AddedNodes, node)
+			diff.Summary.AddedNodes++
+			continue
+		}
+		changes := diffProperties(diffNodeFields(before), diffNodeFields(after), nil, ignored)
+		changes = append(changes, diffProperties(before.Properties, after.Properties, opts.Properties, ignored)...)
+		if len(changes) > 0 {
+			g := group(node.Kind, node.Domain)
+			g.ChangedNodes = append(g.ChangedNodes, NodeChange{DiffNode: node, Changes: changes})
+			diff.Summary.ChangedNodes++
+		}
+	}
+	for objectID, before := range fromNodes {
+		if _, ok := toNodes[objectID]; !ok {
+			node := diffNodeOf(objectID, before)
+			g := group(node.Kind, node.Domain)
+			g.RemovedNodes = append(g.RemovedNodes, node)
+			diff.Summary.RemovedNodes++
+		}
+	}
+
+	fromEdges, toEdges := diffEdgesByKey(from), diffEdgesByKey(to)
+	for key, edge := range toEdges {
+		if _, ok := fromEdges[key]; !ok {
+			g := group(string(edge.Kind), edge.Start.Domain)
+			g.AddedEdges = append(g.AddedEdges, edge)
+			diff.Summary.AddedEdges++
+		}
+	}
+	for key, edge := range fromEdges {
+		if _, ok := toEdges[key]; !ok {
+			g := group(string(edge.Kind), edge.Start.Domain)
+			g.RemovedEdges = append(g.RemovedEdges, edge)
+			diff.Summary.RemovedEdges++
+		}
+	}
+
+	for _, g := range groups {
+		sortDiffNodes(g.AddedNodes)
+		sortDiffNodes(g.RemovedNodes)
+		sort.Slice(g.ChangedNodes, func(i, j int) bool { return diffNodeLess(g.ChangedNodes[i].DiffNode, g.ChangedNodes[j].DiffNode) })
+		sortDiffEdges(g.AddedEdges)
+		sortDiffEdges(g.RemovedEdges)
+		diff.Groups = append(diff.Groups, *g)
+	}
+	sort.Slice(diff.Groups, func(i, j int) bool {
+		if diff.Groups[i].Domain != diff.Groups[j].Domain {
+			return diff.Groups[i].Domain < diff.Groups[j].Domain
+		}
+		return diff.Groups[i].Kind < diff.Groups[j].Kind
+	})
+	return diff
+}
+
+// diffNodesByObjectID indexes the nodes of a graph by upper-case Object ID.
+func diffNodesByObjectID(g *Graph) map[string]GraphNodeProperties {
+	nodes := make(map[string]GraphNodeProperties, len(g.nodes))
+	for _, node := range g.nodes {
+		if objectID := objectIDOf(node); objectID != "" {
+			nodes[strings.ToUpper(objectID)] = node
+		}
+	}
+	return nodes
+}
+
+// diffEdgesByKey indexes the edges of a graph by start Object ID, kind and end Object ID.
+func diffEdgesByKey(g *Graph) map[string]DiffEdge {
+	edges := make(map[string]DiffEdge, len(g.edges))
+	for _, edge := range g.edges {
+		start, end := g.nodes[edge.Source], g.nodes[edge.Target]
+		startID, endID := strings.ToUpper(objectIDOf(start)), strings.ToUpper(objectIDOf(end))
+		if startID == "" || endID == "" {
+			continue
+		}
+		kind := edge.EdgeKind()
+		edges[startID+"|"+string(kind)+"|"+endID] = DiffEdge{Kind: kind, Start: diffNodeOf(startID, start), End: diffNodeOf(endID, end)}
+	}
+	return edges
+}
+
+func diffNodeOf(objectID string, node GraphNodeProperties) DiffNode {
+	domain, _ := node.Properties["domain"].(string)
+	if domain == "" {
+		domain, _ = node.Properties["tenantid"].(string)
+	}
+	return DiffNode{ObjectID: objectID, Name: node.Name, Kind: node.Kind, Domain: strings.ToUpper(domain)}
+}
+
+// diffNodeFields returns the node fields compared by DiffGraphs. Kinds are sorted, since
+// their order is not significant.
+func diffNodeFields(node GraphNodeProperties) map[string]interface{} {
+	kinds := append([]string{}, node.Kinds...)
+	sort.Strings(kinds)
+	return map[string]interface{}{DiffFieldName: node.Name, DiffFieldKind: node.Kind, DiffFieldKinds: kinds}
+}
+
+// diffProperties returns the changed properties, sorted by name.
+func diffProperties(before, after map[string]interface{}, only []string, ignored map[string]bool) []PropertyChange {
+	keys := only
+	if len(keys) == 0 {
+		seen := map[string]bool{}
+		for _, properties := range []map[string]interface{}{before, after} {
+			for key := range properties {
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+
+	var changes []PropertyChange
+	for _, key := range keys {
+		if ignored[key] {
+			continue
+		}
+		if !reflect.DeepEqual(before[key], after[key]) {
+			changes = append(changes, PropertyChange{Property: key, Before: before[key], After: after[key]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Property < changes[j].Property })
+	return changes
+}
+
+func diffNodeLess(a, b DiffNode) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.ObjectID < b.ObjectID
+}
+
+func sortDiffNodes(nodes []DiffNode) {
+	sort.Slice(nodes, func(i, j int) bool { return diffNodeLess(nodes[i], nodes[j]) })
+}
+
+func sortDiffEdges(edges []DiffEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Start != edges[j].Start {
+			return diffNodeLess(edges[i].Start, edges[j].Start)
+		}
+		return diffNodeLess(edges[i].End, edges[j].End)
+	})
+}
+
+// WriteJSON writes the diff as indented JSON.
+func (d *GraphDiff) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
+
+// WriteMarkdown writes the diff as a Markdown report with a summary table followed by a
+// section per domain and kind.
+func (d *GraphDiff) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# Graph diff")
+	fmt.Fprintln(bw)
+	if !d.From.CreatedAt.IsZero() || !d.To.CreatedAt.IsZero() {
+		fmt.Fprintf(bw, "From %s to %s.\n\n", d.From.CreatedAt.Format("2006-01-02 15:04:05 MST"), d.To.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	}
+
+	fmt.Fprintln(bw, "| Change | Count |")
+	fmt.Fprintln(bw, "| --- | ---: |")
+	fmt.Fprintf(bw, "| Added nodes | %d |\n", d.Summary.AddedNodes)
+	fmt.Fprintf(bw, "| Removed nodes | %d |\n", d.Summary.RemovedNodes)
+	fmt.Fprintf(bw, "| Changed nodes | %d |\n", d.Summary.ChangedNodes)
+	fmt.Fprintf(bw, "| Added edges | %d |\n", d.Summary.AddedEdges)
+	fmt.Fprintf(bw, "| Removed edges | %d |\n", d.Summary.RemovedEdges)
+
+	for _, g := range d.Groups {
+		domain := g.Domain
+		if domain == "" {
+			domain = "(no domain)"
+		}
+		fmt.Fprintf(bw, "\n## %s — %s\n", g.Kind, markdownEscape(domain))
+		for _, node := range g.AddedNodes {
+			fmt.Fprintf(bw, "- Added %s\n", markdownNode(node))
+		}
+		for _, node := range g.RemovedNodes {
+			fmt.Fprintf(bw, "- Removed %s\n", markdownNode(node))
+		}
+		for _, change := range g.ChangedNodes {
+			fmt.Fprintf(bw, "- Changed %s\n", markdownNode(change.DiffNode))
+			for _, property := range change.Changes {
+				fmt.Fprintf(bw, "  - `%s`: %s → %s\n", property.Property, markdownValue(property.Before), markdownValue(property.After))
+			}
+		}
+		for _, edge := range g.AddedEdges {
+			fmt.Fprintf(bw, "- Added %s -%s-> %s\n", markdownNode(edge.Start), edge.Kind, markdownNode(edge.End))
+		}
+		for _, edge := range g.RemovedEdges {
+			fmt.Fprintf(bw, "- Removed %s -%s-> %s\n", markdownNode(edge.Start), edge.Kind, markdownNode(edge.End))
+		}
+	}
+	return bw.Flush()
+}
+
+func markdownNode(node DiffNode) string {
+	if node.Name == "" {
+		return "`" + node.ObjectID + "`"
+	}
+	return fmt.Sprintf("**%s** (`%s`)", markdownEscape(node.Name), node.ObjectID)
+}
+
+func markdownValue(value interface{}) string {
+	if value == nil {
+		return "_unset_"
+	}
+	return "`" + propertyString(value) + "`"
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "|", `\|`).Replace(s)
+}
@@ -0,0 +1,104 @@
+package bloodhound
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiffGraphs(t *testing.T) {
+	before := withNodeProperties(testGraph(), "u", map[string]interface{}{"domain": "CORP.LOCAL", "enabled": true, "admincount": true, "lastseen": "2024-01-01"})
+
+	after := NewGraph()
+	for _, id := range before.NodeIDs() {
+		node, _ := before.Node(id)
+		if id == "c" {
+			continue
+		}
+		// Node IDs differ between instances; nodes are matched by Object ID.
+		after.AddNode("new-"+id, node)
+	}
+	withNodeProperties(after, "new-u", map[string]interface{}{"domain": "CORP.LOCAL", "enabled": false, "admincount": true, "lastseen": "2024-02-01"})
+	after.AddNode("new-e", GraphNodeProperties{Name: "BOB@CORP.LOCAL", Kind: "User", ObjectID: "S-1-5-21-1-1105"})
+	for _, edge := range before.Edges() {
+		if edge.Kind == "GenericAll" || edge.Source == "c" || edge.Target == "c" {
+			continue
+		}
+		after.AddEdge(GraphEdge{Source: "new-" + edge.Source, Target: "new-" + edge.Target, Kind: edge.Kind})
+	}
+	after.AddEdge(GraphEdge{Source: "new-e", Target: "new-d", Kind: "MemberOf"})
+
+	diff := DiffGraphs(before, after, DiffOptions{})
+	want := DiffSummary{AddedNodes: 1, RemovedNodes: 1, ChangedNodes: 1, AddedEdges: 1, RemovedEdges: 3}
+	if diff.Summary != want {
+		t.Fatalf("Summary = %+v, want %+v", diff.Summary, want)
+	}
+
+	var changed *NodeChange
+	for _, g := range diff.Groups {
+		if g.Kind == "User" && g.Domain == "CORP.LOCAL" && len(g.ChangedNodes) == 1 {
+			changed = &g.ChangedNodes[0]
+		}
+	}
+	if changed == nil || len(changed.Changes) != 1 || changed.Changes[0].Property != "enabled" {
+		t.Fatalf("unexpected node changes: %+v", diff.Groups)
+	}
+
+	if !DiffGraphs(before, before, DiffOptions{}).IsEmpty() {
+		t.Error("diff of a graph with itself is not empty")
+	}
+
+	var markdown bytes.Buffer
+	if err := diff.WriteMarkdown(&markdown); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"| Removed edges | 3 |",
+		"- Removed **B@CORP.LOCAL** (`S-1-5-21-1-1201`) -GenericAll-> **DOMAIN ADMINS@CORP.LOCAL** (`S-1-5-21-1-512`)",
+		"  - `enabled`: `true` → `false`",
+	} {
+		if !strings.Contains(markdown.String(), line) {
+			t.Errorf("Markdown is missing %q:\n%s", line, markdown.String())
+		}
+	}
+}
+
+func TestDiffGraphsNodeFields(t *testing.T) {
+	node := GraphNodeProperties{Name: "WS01.CORP.LOCAL", Kind: "Computer", Kinds: []string{"Base", "Computer"}, ObjectID: "S-1-5-21-1-1001",
+		Properties: map[string]interface{}{"domain": "CORP.LOCAL"}}
+	tests := []struct {
+		name   string
+		change func(*GraphNodeProperties)
+		ignore []string
+		want   []string
+	}{
+		{"unchanged", func(n *GraphNodeProperties) {}, nil, nil},
+		{"kinds reordered", func(n *GraphNodeProperties) { n.Kinds = []string{"Computer", "Base"} }, nil, nil},
+		{"name", func(n *GraphNodeProperties) { n.Name = "WS02.CORP.LOCAL" }, nil, []string{DiffFieldName}},
+		{"kind", func(n *GraphNodeProperties) { n.Kind = "User" }, nil, []string{DiffFieldKind}},
+		{"tagged", func(n *GraphNodeProperties) { n.Kinds = append(n.Kinds, KindTagTierZero) }, nil, []string{DiffFieldKinds}},
+		{"ignored", func(n *GraphNodeProperties) { n.Kinds = append(n.Kinds, KindTagTierZero) }, []string{DiffFieldKinds}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := NewGraph(), NewGraph()
+			before.AddNode("n", node)
+			changed := node
+			changed.Kinds = append([]string{}, node.Kinds...)
+			tt.change(&changed)
+			after.AddNode("n", changed)
+
+			var got []string
+			for _, g := range DiffGraphs(before, after, DiffOptions{Properties: []string{"enabled"}, Ignore: tt.ignore}).Groups {
+				for _, c := range g.ChangedNodes {
+					for _, change := range c.Changes {
+						got = append(got, change.Property)
+					}
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("changed fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
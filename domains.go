@@ -1,29 +1,17 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
 // GetDomain fetches a single domain by its Object ID (SID).
 func (c *Client) GetDomain(objectID string) (*Domain, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/domains/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get domain failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -44,8 +32,8 @@ func (c *Client) GetDomain(objectID string) (*Domain, error) {
 			OutboundTrusts              int      `json:"outboundTrusts"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get domain response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
 	}
 	domain := response.Data.Props
 	domain.ObjectType = "Domain"
@@ -68,18 +56,11 @@ func (c *Client) GetDomain(objectID string) (*Domain, error) {
 
 // GetDomainByName fetches a single domain by its name.
 func (c *Client) GetDomainByName(domainName string) (*Domain, error) {
-	searchResponse, err := c.Search(domainName, "Domain", 0)
+	objectID, err := c.resolveObjectID("Domain", domainName)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, domainName) {
-			return c.GetDomain(result.ObjectID)
-		}
-	}
-
-	return nil, fmt.Errorf("domain not found: %s", domainName)
+	return c.GetDomain(objectID)
 }
 
 // GetDomainUsers fetches the users in a given domain.
@@ -91,19 +72,8 @@ func (c *Client) GetDomainUsers(objectID string, limit int) (UsersResponse, erro
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainComputers fetches the computers in a given domain.
@@ -115,19 +85,8 @@ func (c *Client) GetDomainComputers(objectID string, limit int) (ComputersRespon
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainForeignUsers fetches the foreign users in a given domain.
@@ -139,19 +98,8 @@ func (c *Client) GetDomainForeignUsers(objectID string, limit int) (ForeignPrinc
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainInboundTrusts fetches the inbound trusts for a given domain.
@@ -163,19 +111,8 @@ func (c *Client) GetDomainInboundTrusts(objectID string, limit int) (DomainTrust
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainOutboundTrusts fetches the outbound trusts for a given domain.
@@ -187,19 +124,8 @@ func (c *Client) GetDomainOutboundTrusts(objectID string, limit int) (DomainTrus
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainOUs fetches the OUs in a given domain.
@@ -211,19 +137,8 @@ func (c *Client) GetDomainOUs(objectID string, limit int) (OUsResponse, error) {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainLinkedGPOs fetches the linked GPOs in a given domain.
@@ -235,19 +150,8 @@ func (c *Client) GetDomainLinkedGPOs(objectID string, limit int) (GPOsResponse,
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainGroups fetches the groups in a given domain.
@@ -259,19 +163,8 @@ func (c *Client) GetDomainGroups(objectID string, limit int) (GroupsResponse, er
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainGPOs fetches the GPOs in a given domain.
@@ -283,19 +176,8 @@ func (c *Client) GetDomainGPOs(objectID string, limit int) (GPOsResponse, error)
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainForeignGroups fetches the foreign groups in a given domain.
@@ -307,19 +189,8 @@ func (c *Client) GetDomainForeignGroups(objectID string, limit int) (ForeignPrin
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainForeignGPOControllers fetches the foreign GPO controllers in a given domain.
@@ -331,19 +202,8 @@ func (c *Client) GetDomainForeignGPOControllers(objectID string, limit int) (For
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainForeignAdmins fetches the foreign admins in a given domain.
@@ -355,19 +215,8 @@ func (c *Client) GetDomainForeignAdmins(objectID string, limit int) (ForeignPrin
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainDCSyncers fetches the principals with DCSync rights to a given domain.
@@ -379,19 +228,8 @@ func (c *Client) GetDomainDCSyncers(objectID string, limit int) (ForeignPrincipa
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetDomainControllers fetches the controllers of a given domain.
@@ -403,19 +241,8 @@ func (c *Client) GetDomainControllers(objectID string, limit int) (ControllersRe
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // ListDomains fetches all domains.
@@ -424,17 +251,52 @@ func (c *Client) ListDomains() ([]AvailableDomain, error) {
 		Data []AvailableDomain `json:"data"`
 	}
 	apiUrl := c.baseURL.JoinPath("/api/v2/available-domains")
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
 		return nil, err
 	}
-	resp, err := c.do(req, nil)
+	return response.Data, nil
+}
+
+// GetDomainUnlinkedGPOs fetches the GPOs in a given domain that are not linked anywhere in it.
+func (c *Client) GetDomainUnlinkedGPOs(objectID string) ([]RelatedEntity, error) {
+	all, err := c.listAllDomainEntities(objectID, "/gpos")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	linked, err := c.listAllDomainEntities(objectID, "/linked-gpos")
+	if err != nil {
 		return nil, err
 	}
-	return response.Data, nil
-}
\ No newline at end of file
+
+	isLinked := make(map[string]bool, len(linked))
+	for _, gpo := range linked {
+		isLinked[strings.ToUpper(gpo.ObjectID)] = true
+	}
+
+	unlinked := []RelatedEntity{}
+	for _, gpo := range all {
+		if !isLinked[strings.ToUpper(gpo.ObjectID)] {
+			unlinked = append(unlinked, gpo)
+		}
+	}
+	return unlinked, nil
+}
+
+// listAllDomainEntities pages through a relationship list of a domain, e.g. "/gpos",
+// until every entity has been read.
+func (c *Client) listAllDomainEntities(objectID, relation string) ([]RelatedEntity, error) {
+	return listAll(listPageSize, func(skip, limit int) ([]RelatedEntity, int, error) {
+		apiUrl := c.baseURL.JoinPath("/api/v2/domains/", objectID, relation)
+		params := url.Values{}
+		params.Add("skip", strconv.Itoa(skip))
+		params.Add("limit", strconv.Itoa(limit))
+		apiUrl.RawQuery = params.Encode()
+
+		var page GPOsResponse
+		if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &page); err != nil {
+			return nil, 0, err
+		}
+		entities, err := page.Entities()
+		return entities, page.Count, err
+	})
+}
@@ -0,0 +1,50 @@
+package bloodhound
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestGetDomainUnlinkedGPOs(t *testing.T) {
+	const domainID = "S-1-5-21-1"
+	gpos := make([]RelatedEntity, 1203)
+	for i := range gpos {
+		gpos[i] = RelatedEntity{Name: fmt.Sprintf("GPO%04d@CORP.LOCAL", i), ObjectID: fmt.Sprintf("GPO-%04d", i), ObjectType: "GPO"}
+	}
+	linked := []RelatedEntity{gpos[0], gpos[600], gpos[1202]}
+
+	requests := 0
+	page := func(entities []RelatedEntity) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			end := min(skip+limit, len(entities))
+			data, _ := json.Marshal(entities[min(skip, end):end])
+			writeTestJSON(w, http.StatusOK, fmt.Sprintf(`{"count": %d, "skip": %d, "limit": %d, "data": %s}`, len(entities), skip, limit, data))
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/domains/"+domainID+"/gpos", page(gpos))
+	mux.HandleFunc("/api/v2/domains/"+domainID+"/linked-gpos", page(linked))
+	client := newTestClient(t, mux)
+
+	unlinked, err := client.GetDomainUnlinkedGPOs(domainID)
+	if err != nil {
+		t.Fatalf("GetDomainUnlinkedGPOs returned an error: %v", err)
+	}
+	if len(unlinked) != len(gpos)-len(linked) {
+		t.Fatalf("got %d unlinked GPOs, want %d", len(unlinked), len(gpos)-len(linked))
+	}
+	for _, gpo := range unlinked {
+		if gpo.ObjectID == "GPO-0000" || gpo.ObjectID == "GPO-0600" || gpo.ObjectID == "GPO-1202" {
+			t.Errorf("linked GPO %s reported as unlinked", gpo.ObjectID)
+		}
+	}
+	if requests != 4 {
+		t.Errorf("got %d requests, want 3 pages of GPOs and 1 of linked GPOs", requests)
+	}
+}
@@ -0,0 +1,159 @@
+package bloodhound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Entity is a graph object of any kind fetched by its Object ID.
+type Entity interface {
+	// Kind is the primary node kind, e.g. "User", "Computer" or "AZServicePrincipal".
+	Kind() string
+	ObjectID() string
+	Name() string
+	// Properties returns the raw node properties as reported by the server.
+	Properties() map[string]interface{}
+	// Value returns the typed struct for the kind, e.g. *ADUser for "User" or *AzureApp
+	// for "AZApp". Kinds without a dedicated struct return the raw properties map.
+	Value() interface{}
+}
+
+type entity struct {
+	kind       string
+	objectID   string
+	name       string
+	properties map[string]interface{}
+	value      interface{}
+}
+
+func (e *entity) Kind() string                       { return e.kind }
+func (e *entity) ObjectID() string                   { return e.objectID }
+func (e *entity) Name() string                       { return e.name }
+func (e *entity) Properties() map[string]interface{} { return e.properties }
+func (e *entity) Value() interface{}                 { return e.value }
+
+// entityTypes maps each node kind to a constructor for its typed struct.
+var entityTypes = map[string]func() interface{}{
+	"User":                func() interface{} { return &ADUser{} },
+	"Computer":            func() interface{} { return &Computer{} },
+	"Group":               func() interface{} { return &Group{} },
+	"GPO":                 func() interface{} { return &GPO{} },
+	"OU":                  func() interface{} { return &OU{} },
+	"Container":           func() interface{} { return &Container{} },
+	"Domain":              func() interface{} { return &Domain{} },
+	"AIACA":               func() interface{} { return &AIACA{} },
+	"RootCA":              func() interface{} { return &RootCA{} },
+	"EnterpriseCA":        func() interface{} { return &EnterpriseCA{} },
+	"NTAuthStore":         func() interface{} { return &NTAuthStore{} },
+	"CertTemplate":        func() interface{} { return &CertTemplate{} },
+	"IssuancePolicy":      func() interface{} { return &IssuancePolicy{} },
+	"AZUser":              func() interface{} { return &AzureUser{} },
+	"AZGroup":             func() interface{} { return &AzureGroup{} },
+	"AZVM":                func() interface{} { return &AzureVM{} },
+	"AZTenant":            func() interface{} { return &AzureTenant{} },
+	"AZServicePrincipal":  func() interface{} { return &AzureServicePrincipal{} },
+	"AZApp":               func() interface{} { return &AzureApp{} },
+	"AZDevice":            func() interface{} { return &AzureDevice{} },
+	"AZRole":              func() interface{} { return &AzureRole{} },
+	"AZManagementGroup":   func() interface{} { return &AzureManagementGroup{} },
+	"AZSubscription":      func() interface{} { return &AzureSubscription{} },
+	"AZResourceGroup":     func() interface{} { return &AzureResourceGroup{} },
+	"AZKeyVault":          func() interface{} { return &AzureKeyVault{} },
+	"AZAutomationAccount": func() interface{} { return &AzureAutomationAccount{} },
+	"AZContainerRegistry": func() interface{} { return &AzureContainerRegistry{} },
+	"AZFunctionApp":       func() interface{} { return &AzureFunctionApp{} },
+	"AZLogicApp":          func() interface{} { return &AzureLogicApp{} },
+	"AZWebApp":            func() interface{} { return &AzureWebApp{} },
+	"AZManagedCluster":    func() interface{} { return &AzureManagedCluster{} },
+	"AZVMScaleSet":        func() interface{} { return &AzureVMScaleSet{} },
+}
+
+// GetEntity fetches any AD or Azure object by its Object ID without knowing its kind
+// in advance. The properties come from the base entity endpoint, or from the Azure entity
+// endpoint for objects outside the AD graph. Neither reports the node kind, so it is read
+// from the graph with Cypher; when Cypher is unavailable or finds nothing the kind is
+// KindBase or KindAZBase.
+// An unknown Object ID returns an error wrapping ErrNotFound.
+func (c *Client) GetEntity(ctx context.Context, objectID string) (Entity, error) {
+	kind := string(KindBase)
+	props, err := c.getEntityProperties(ctx, c.baseURL.JoinPath("/api/v2/base/", objectID))
+	if errors.Is(err, ErrNotFound) {
+		kind = string(KindAZBase)
+		props, err = c.getEntityProperties(ctx, c.baseURL.JoinPath("/api/v2/azure/entities/", objectID))
+	}
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("entity %s: %w", objectID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("MATCH (n) WHERE n.objectid = %s RETURN n LIMIT 1", cypherString(strings.ToUpper(objectID)))
+	graph, err := c.RunCypherGraph(ctx, query)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err == nil {
+		for _, node := range graph.Nodes {
+			if node.Kind != "" {
+				kind = node.Kind
+			}
+		}
+	}
+	return newEntity(kind, objectID, props)
+}
+
+// getEntityProperties fetches the raw properties of a node from an entity endpoint.
+func (c *Client) getEntityProperties(ctx context.Context, apiUrl *url.URL) (map[string]interface{}, error) {
+	var response struct {
+		Data struct {
+			Props map[string]interface{} `json:"props"`
+		} `json:"data"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
+	}
+	if response.Data.Props == nil {
+		return nil, ErrNotFound
+	}
+	return response.Data.Props, nil
+}
+
+// newEntity decodes raw node properties into the typed struct for the kind.
+func newEntity(kind, objectID string, props map[string]interface{}) (*entity, error) {
+	e := &entity{kind: kind, objectID: objectID, properties: props, value: props}
+	if name, ok := props["name"].(string); ok {
+		e.name = name
+	}
+	if id, ok := props["objectid"].(string); ok && id != "" {
+		e.objectID = id
+	}
+
+	newValue, ok := entityTypes[kind]
+	if !ok {
+		return e, nil
+	}
+
+	// The entity endpoints do not report the kind, so it is filled in before decoding.
+	withKind := make(map[string]interface{}, len(props)+1)
+	for key, value := range props {
+		withKind[key] = value
+	}
+	withKind["type"] = kind
+
+	raw, err := json.Marshal(withKind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s properties: %w", kind, err)
+	}
+	value := newValue()
+	if err := json.Unmarshal(raw, value); err != nil {
+		return nil, fmt.Errorf("failed to decode %s properties: %w", kind, err)
+	}
+	e.value = value
+	return e, nil
+}
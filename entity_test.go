@@ -0,0 +1,68 @@
+package bloodhound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetEntity(t *testing.T) {
+	notFound := func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/base/S-1-5-21-1-1104", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, `{"data": {"props": {"objectid": "S-1-5-21-1-1104", "name": "ALICE@CORP.LOCAL", "enabled": true}}}`)
+	})
+	mux.HandleFunc("/api/v2/base/", notFound)
+	mux.HandleFunc("/api/v2/azure/entities/11111111-2222-3333-4444-555555555555", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, `{"data": {"props": {"objectid": "11111111-2222-3333-4444-555555555555", "name": "PAYROLL", "appid": "APP-1"}}}`)
+	})
+	mux.HandleFunc("/api/v2/azure/entities/", notFound)
+	mux.HandleFunc("/api/v2/search", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("GetEntity should not search, got %s", r.URL)
+		notFound(w, r)
+	})
+	mux.HandleFunc("/api/v2/graphs/cypher", cypherHandler(t, map[string]string{
+		`"S-1-5-21-1-1104"`:                      `{"nodes": {"1": {"label": "ALICE@CORP.LOCAL", "kind": "User", "objectId": "S-1-5-21-1-1104"}}, "edges": []}`,
+		`"11111111-2222-3333-4444-555555555555"`: `{"nodes": {"2": {"label": "PAYROLL", "kind": "AZApp", "objectId": "11111111-2222-3333-4444-555555555555"}}, "edges": []}`,
+	}))
+	client := newTestClient(t, mux)
+
+	user, err := client.GetEntity(context.Background(), "S-1-5-21-1-1104")
+	if err != nil {
+		t.Fatalf("GetEntity returned an error: %v", err)
+	}
+	if aduser, ok := user.Value().(*ADUser); !ok || user.Kind() != "User" || user.Name() != "ALICE@CORP.LOCAL" || !aduser.Enabled {
+		t.Errorf("unexpected user entity: %s %s %#v", user.Kind(), user.Name(), user.Value())
+	}
+
+	app, err := client.GetEntity(context.Background(), "11111111-2222-3333-4444-555555555555")
+	if err != nil {
+		t.Fatalf("GetEntity returned an error: %v", err)
+	}
+	if azureApp, ok := app.Value().(*AzureApp); !ok || app.Kind() != "AZApp" || azureApp.AppID != "APP-1" {
+		t.Errorf("unexpected app entity: %s %#v", app.Kind(), app.Value())
+	}
+
+	if _, err := client.GetEntity(context.Background(), "S-1-5-21-1-9999"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown Object ID, got %v", err)
+	}
+
+	noCypher := http.NewServeMux()
+	noCypher.Handle("/api/v2/base/", mux)
+	noCypher.HandleFunc("/api/v2/graphs/cypher", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusInternalServerError, `{"errors": [{"message": "cypher is disabled"}]}`)
+	})
+	base, err := newTestClient(t, noCypher).GetEntity(context.Background(), "S-1-5-21-1-1104")
+	if err != nil || base.Kind() != string(KindBase) || base.Name() != "ALICE@CORP.LOCAL" {
+		t.Errorf("expected a Base entity without Cypher, got %v (err %v)", base, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetEntity(ctx, "S-1-5-21-1-1104"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package bloodhound
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphFromPaths builds a graph holding the nodes and edges of the given paths.
+func GraphFromPaths(paths ...Path) *Graph {
+	g := NewGraph()
+	for _, path := range paths {
+		for _, node := range path.Nodes {
+			g.AddNode(node.ID, node.GraphNodeProperties)
+		}
+		for _, edge := range path.Edges {
+			if !g.hasEdge(edge) {
+				g.AddEdge(edge)
+			}
+		}
+	}
+	return g
+}
+
+// exportNodeAttributes returns the attributes written for a node: its name, kind, kinds,
+// Object ID and system tags, followed by its properties.
+func exportNodeAttributes(node GraphNodeProperties) map[string]string {
+	attributes := map[string]string{}
+	for key, value := range node.Properties {
+		attributes[key] = propertyString(value)
+	}
+	attributes["name"] = node.Name
+	attributes["kind"] = node.Kind
+	if len(node.Kinds) > 0 {
+		attributes["kinds"] = strings.Join(node.Kinds, ",")
+	}
+	if objectID := objectIDOf(node); objectID != "" {
+		attributes["objectid"] = objectID
+	}
+	if tags := node.Tags(); len(tags) > 0 {
+		attributes["system_tags"] = tags.String()
+	}
+	return attributes
+}
+
+// exportEdgeAttributes returns the attributes written for an edge: its kind and properties.
+func exportEdgeAttributes(edge GraphEdge) map[string]string {
+	attributes := map[string]string{}
+	for key, value := range edge.Properties {
+		attributes[key] = propertyString(value)
+	}
+	attributes["kind"] = string(edge.EdgeKind())
+	return attributes
+}
+
+// propertyString renders a property value as text; values other than strings are JSON-encoded.
+func propertyString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// attributeKeys returns the sorted union of the attribute keys.
+func attributeKeys(attributeSets []map[string]string) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, attributes := range attributeSets {
+		for key := range attributes {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys(attributes map[string]string) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (g *Graph) exportAttributes() (nodeIDs []string, nodes []map[string]string, edges []map[string]string) {
+	nodeIDs = g.NodeIDs()
+	for _, id := range nodeIDs {
+		nodes = append(nodes, exportNodeAttributes(g.nodes[id]))
+	}
+	for _, edge := range g.edges {
+		edges = append(edges, exportEdgeAttributes(edge))
+	}
+	return nodeIDs, nodes, edges
+}
+
+// WriteGraphML writes the graph as GraphML, e.g. for yEd or Gephi. Every node and edge
+// property becomes a string-typed data key.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	nodeIDs, nodeAttributes, edgeAttributes := g.exportAttributes()
+	nodeKeys, edgeKeys := attributeKeys(nodeAttributes), attributeKeys(edgeAttributes)
+
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(bw, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	for i, key := range nodeKeys {
+		fmt.Fprintf(bw, "  <key id=\"n%d\" for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", i, xmlEscape(key))
+	}
+	for i, key := range edgeKeys {
+		fmt.Fprintf(bw, "  <key id=\"e%d\" for=\"edge\" attr.name=\"%s\" attr.type=\"string\"/>\n", i, xmlEscape(key))
+	}
+	fmt.Fprintln(bw, `  <graph id="bloodhound" edgedefault="directed">`)
+	for i, id := range nodeIDs {
+		fmt.Fprintf(bw, "    <node id=\"%s\">\n", xmlEscape(id))
+		for k, key := range nodeKeys {
+			if value, ok := nodeAttributes[i][key]; ok {
+				fmt.Fprintf(bw, "      <data key=\"n%d\">%s</data>\n", k, xmlEscape(value))
+			}
+		}
+		fmt.Fprintln(bw, "    </node>")
+	}
+	for i, edge := range g.edges {
+		fmt.Fprintf(bw, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, xmlEscape(edge.Source), xmlEscape(edge.Target))
+		for k, key := range edgeKeys {
+			if value, ok := edgeAttributes[i][key]; ok {
+				fmt.Fprintf(bw, "      <data key=\"e%d\">%s</data>\n", k, xmlEscape(value))
+			}
+		}
+		fmt.Fprintln(bw, "    </edge>")
+	}
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+	return bw.Flush()
+}
+
+// WriteGEXF writes the graph as GEXF 1.3, e.g. for Gephi. Node names become labels and
+// every property becomes a string attribute.
+func (g *Graph) WriteGEXF(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	nodeIDs, nodeAttributes, edgeAttributes := g.exportAttributes()
+	nodeKeys, edgeKeys := attributeKeys(nodeAttributes), attributeKeys(edgeAttributes)
+
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(bw, `<gexf xmlns="http://gexf.net/1.3" version="1.3">`)
+	fmt.Fprintln(bw, `  <graph defaultedgetype="directed" mode="static">`)
+	writeGEXFAttributes(bw, "node", nodeKeys)
+	writeGEXFAttributes(bw, "edge", edgeKeys)
+
+	fmt.Fprintln(bw, "    <nodes>")
+	for i, id := range nodeIDs {
+		label := nodeAttributes[i]["name"]
+		if label == "" {
+			label = id
+		}
+		fmt.Fprintf(bw, "      <node id=\"%s\" label=\"%s\">\n", xmlEscape(id), xmlEscape(label))
+		writeGEXFValues(bw, nodeKeys, nodeAttributes[i])
+		fmt.Fprintln(bw, "      </node>")
+	}
+	fmt.Fprintln(bw, "    </nodes>")
+
+	fmt.Fprintln(bw, "    <edges>")
+	for i, edge := range g.edges {
+		fmt.Fprintf(bw, "      <edge id=\"%d\" source=\"%s\" target=\"%s\" label=\"%s\">\n",
+			i, xmlEscape(edge.Source), xmlEscape(edge.Target), xmlEscape(string(edge.EdgeKind())))
+		writeGEXFValues(bw, edgeKeys, edgeAttributes[i])
+		fmt.Fprintln(bw, "      </edge>")
+	}
+	fmt.Fprintln(bw, "    </edges>")
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</gexf>")
+	return bw.Flush()
+}
+
+func writeGEXFAttributes(w io.Writer, class string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "    <attributes class=\"%s\">\n", class)
+	for i, key := range keys {
+		fmt.Fprintf(w, "      <attribute id=\"%d\" title=\"%s\" type=\"string\"/>\n", i, xmlEscape(key))
+	}
+	fmt.Fprintln(w, "    </attributes>")
+}
+
+func writeGEXFValues(w io.Writer, keys []string, attributes map[string]string) {
+	if len(attributes) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "        <attvalues>")
+	for i, key := range keys {
+		if value, ok := attributes[key]; ok {
+			fmt.Fprintf(w, "          <attvalue for=\"%d\" value=\"%s\"/>\n", i, xmlEscape(value))
+		}
+	}
+	fmt.Fprintln(w, "        </attvalues>")
+}
+
+// DOTNodeStyle is the Graphviz styling applied to the nodes of a kind.
+type DOTNodeStyle struct {
+	Shape     string
+	FillColor string
+}
+
+// DefaultDOTNodeStyles styles the common node kinds after the BloodHound UI.
+var DefaultDOTNodeStyles = map[string]DOTNodeStyle{
+	"User":           {Shape: "ellipse", FillColor: "#17E625"},
+	"Group":          {Shape: "box", FillColor: "#DBE617"},
+	"Computer":       {Shape: "box", FillColor: "#E67873"},
+	"Domain":         {Shape: "hexagon", FillColor: "#17E6B9"},
+	"GPO":            {Shape: "note", FillColor: "#998E4C"},
+	"OU":             {Shape: "folder", FillColor: "#FFAA00"},
+	"Container":      {Shape: "folder", FillColor: "#F79A78"},
+	"CertTemplate":   {Shape: "component", FillColor: "#B153F3"},
+	"EnterpriseCA":   {Shape: "component", FillColor: "#4696E9"},
+	"RootCA":         {Shape: "component", FillColor: "#6968E8"},
+	"AIACA":          {Shape: "component", FillColor: "#9769F0"},
+	"NTAuthStore":    {Shape: "component", FillColor: "#D575F5"},
+	"IssuancePolicy": {Shape: "component", FillColor: "#99B2DD"},
+	"AZUser":         {Shape: "ellipse", FillColor: "#34D2EB"},
+	"AZGroup":        {Shape: "box", FillColor: "#F57C9B"},
+	"AZTenant":       {Shape: "hexagon", FillColor: "#54F2F2"},
+}
+
+// DOTOptions tunes WriteDOT.
+type DOTOptions struct {
+	// NodeStyles overrides DefaultDOTNodeStyles per kind.
+	NodeStyles map[string]DOTNodeStyle
+	// IncludeProperties adds every property to the node and edge tooltips.
+	IncludeProperties bool
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, styling nodes by kind and labelling
+// edges with their kind.
+func (g *Graph) WriteDOT(w io.Writer, opts DOTOptions) error {
+	bw := bufio.NewWriter(w)
+	nodeIDs, nodeAttributes, edgeAttributes := g.exportAttributes()
+
+	fmt.Fprintln(bw, "digraph bloodhound {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [style=filled, fontname="Helvetica"];`)
+	fmt.Fprintln(bw, `  edge [fontname="Helvetica", fontsize=10];`)
+	for i, id := range nodeIDs {
+		node := g.nodes[id]
+		label := node.Name
+		if label == "" {
+			label = id
+		}
+		style, ok := opts.NodeStyles[node.Kind]
+		if !ok {
+			style, ok = DefaultDOTNodeStyles[node.Kind]
+		}
+		if !ok {
+			style = DOTNodeStyle{Shape: "ellipse", FillColor: "#DDDDDD"}
+		}
+		fmt.Fprintf(bw, "  %s [label=%s, shape=%s, fillcolor=%s",
+			strconv.Quote(id), strconv.Quote(label), strconv.Quote(style.Shape), strconv.Quote(style.FillColor))
+		if opts.IncludeProperties {
+			fmt.Fprintf(bw, ", tooltip=%s", strconv.Quote(dotTooltip(nodeAttributes[i])))
+		}
+		fmt.Fprintln(bw, "];")
+	}
+	for i, edge := range g.edges {
+		fmt.Fprintf(bw, "  %s -> %s [label=%s", strconv.Quote(edge.Source), strconv.Quote(edge.Target), strconv.Quote(string(edge.EdgeKind())))
+		if opts.IncludeProperties {
+			fmt.Fprintf(bw, ", tooltip=%s", strconv.Quote(dotTooltip(edgeAttributes[i])))
+		}
+		fmt.Fprintln(bw, "];")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func dotTooltip(attributes map[string]string) string {
+	lines := make([]string, 0, len(attributes))
+	for _, key := range sortedKeys(attributes) {
+		lines = append(lines, key+": "+attributes[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WriteCytoscapeJSON writes the graph in the Cytoscape.js elements JSON format. Node and
+// edge properties are kept under "data" with their original types.
+func (g *Graph) WriteCytoscapeJSON(w io.Writer) error {
+	type element struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	var document struct {
+		Elements struct {
+			Nodes []element `json:"nodes"`
+			Edges []element `json:"edges"`
+		} `json:"elements"`
+	}
+	document.Elements.Nodes = []element{}
+	document.Elements.Edges = []element{}
+
+	for _, id := range g.NodeIDs() {
+		node := g.nodes[id]
+		data := map[string]interface{}{}
+		for key, value := range node.Properties {
+			data[key] = value
+		}
+		data["id"] = id
+		data["label"] = node.Name
+		data["kind"] = node.Kind
+		if len(node.Kinds) > 0 {
+			data["kinds"] = node.Kinds
+		}
+		if objectID := objectIDOf(node); objectID != "" {
+			data["objectid"] = objectID
+		}
+		document.Elements.Nodes = append(document.Elements.Nodes, element{Data: data})
+	}
+	for i, edge := range g.edges {
+		data := map[string]interface{}{}
+		for key, value := range edge.Properties {
+			data[key] = value
+		}
+		data["id"] = fmt.Sprintf("e%d", i)
+		data["source"] = edge.Source
+		data["target"] = edge.Target
+		data["label"] = string(edge.EdgeKind())
+		document.Elements.Edges = append(document.Elements.Edges, element{Data: data})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
@@ -0,0 +1,65 @@
+package bloodhound
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGraphExport(t *testing.T) {
+	g := withNodeProperties(testGraph(), "u", map[string]interface{}{"enabled": true, "description": "a <b> & c"})
+
+	for name, write := range map[string]func(*bytes.Buffer) error{
+		"GraphML": func(b *bytes.Buffer) error { return g.WriteGraphML(b) },
+		"GEXF":    func(b *bytes.Buffer) error { return g.WriteGEXF(b) },
+	} {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		decoder := xml.NewDecoder(&buf)
+		for {
+			if _, err := decoder.Token(); err != nil {
+				if err != io.EOF {
+					t.Errorf("%s is not well-formed: %v", name, err)
+				}
+				break
+			}
+		}
+	}
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot, DOTOptions{IncludeProperties: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"u" -> "a" [label="MemberOf"`, `shape="ellipse", fillcolor="#17E625"`, `enabled: true`} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output is missing %q:\n%s", want, dot.String())
+		}
+	}
+
+	var cytoscape bytes.Buffer
+	if err := g.WriteCytoscapeJSON(&cytoscape); err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		Elements struct {
+			Nodes []struct{ Data map[string]interface{} } `json:"nodes"`
+			Edges []struct{ Data map[string]interface{} } `json:"edges"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(cytoscape.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+	if len(document.Elements.Nodes) != 5 || len(document.Elements.Edges) != 6 {
+		t.Fatalf("unexpected element counts: %d nodes, %d edges", len(document.Elements.Nodes), len(document.Elements.Edges))
+	}
+	for _, node := range document.Elements.Nodes {
+		if node.Data["id"] == "u" && node.Data["enabled"] != true {
+			t.Errorf("node properties were not kept: %v", node.Data)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package bloodhound
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// FindingMetadata holds the descriptive text the BloodHound UI renders for a finding type.
+// All text fields are Markdown.
+type FindingMetadata struct {
+	Finding          string
+	Title            string
+	ShortDescription string
+	LongDescription  string
+	ShortRemediation string
+	LongRemediation  string
+	References       string
+}
+
+// findingAssetFiles maps each FindingMetadata field to the asset file the server serves it from.
+var findingAssetFiles = []struct {
+	name  string
+	field func(*FindingMetadata) *string
+}{
+	{"title.md", func(m *FindingMetadata) *string { return &m.Title }},
+	{"short_description.md", func(m *FindingMetadata) *string { return &m.ShortDescription }},
+	{"long_description.md", func(m *FindingMetadata) *string { return &m.LongDescription }},
+	{"short_remediation.md", func(m *FindingMetadata) *string { return &m.ShortRemediation }},
+	{"long_remediation.md", func(m *FindingMetadata) *string { return &m.LongRemediation }},
+	{"references.md", func(m *FindingMetadata) *string { return &m.References }},
+}
+
+// GetFindingMetadata fetches the title, descriptions, remediation guidance and references
+// for a finding type. Asset files the server does not provide are left empty.
+func (c *Client) GetFindingMetadata(findingType string) (FindingMetadata, error) {
+	metadata := FindingMetadata{Finding: findingType}
+	found := false
+	for _, file := range findingAssetFiles {
+		text, ok, err := c.getFindingAsset(findingType, file.name)
+		if err != nil {
+			return metadata, err
+		}
+		if ok {
+			*file.field(&metadata) = text
+			found = true
+		}
+	}
+	if !found {
+		return metadata, fmt.Errorf("no metadata available for finding type %s: %w", findingType, ErrNotFound)
+	}
+	return metadata, nil
+}
+
+// getFindingAsset fetches a single Markdown asset for a finding type. A 404 is reported as not found
+// rather than as an error, since not every finding type ships every file.
+func (c *Client) getFindingAsset(findingType, fileName string) (string, bool, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/assets/findings/", findingType, fileName)
+	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create finding asset request: %w", err)
+	}
+	req.Header.Set("Accept", "text/markdown, text/plain, */*")
+
+	resp, err := c.do(req, nil)
+	if errors.Is(err, ErrNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to execute finding asset request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read finding asset %s/%s: %w", findingType, fileName, err)
+	}
+	return strings.TrimSpace(string(body)), true, nil
+}
+
+// FindingCatalog is a cache of finding metadata keyed by finding type.
+// It is safe for concurrent use.
+type FindingCatalog struct {
+	client  *Client
+	mu      sync.Mutex
+	entries map[string]FindingMetadata
+}
+
+// NewFindingCatalog returns an empty catalog that fetches metadata through the given client.
+func NewFindingCatalog(client *Client) *FindingCatalog {
+	return &FindingCatalog{client: client, entries: map[string]FindingMetadata{}}
+}
+
+// Get returns the metadata for a finding type, fetching it from the server on first use.
+func (fc *FindingCatalog) Get(findingType string) (FindingMetadata, error) {
+	fc.mu.Lock()
+	metadata, ok := fc.entries[findingType]
+	fc.mu.Unlock()
+	if ok {
+		return metadata, nil
+	}
+
+	metadata, err := fc.client.GetFindingMetadata(findingType)
+	if err != nil {
+		return metadata, err
+	}
+
+	fc.mu.Lock()
+	fc.entries[findingType] = metadata
+	fc.mu.Unlock()
+	return metadata, nil
+}
+
+// Load fetches and caches the metadata for every finding type the server knows about.
+func (fc *FindingCatalog) Load() error {
+	findingTypes, err := fc.client.ListAttackPathTypes()
+	if err != nil {
+		return err
+	}
+	for _, findingType := range findingTypes {
+		if _, err := fc.Get(findingType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns the metadata for the type of the given finding.
+func (fc *FindingCatalog) Describe(finding AttackPathFinding) (FindingMetadata, error) {
+	return fc.Get(finding.Finding)
+}
+
+// Entries returns a copy of every cached entry.
+func (fc *FindingCatalog) Entries() map[string]FindingMetadata {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	entries := make(map[string]FindingMetadata, len(fc.entries))
+	for findingType, metadata := range fc.entries {
+		entries[findingType] = metadata
+	}
+	return entries
+}
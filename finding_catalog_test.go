@@ -0,0 +1,45 @@
+package bloodhound
+
+import (
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFindingMetadata(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/assets/findings/T0DCSync/title.md":
+			w.Write([]byte("DCSync Privileges\n"))
+		case "/api/v2/assets/findings/T0DCSync/references.md":
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte("- https://attack.mitre.org/techniques/T1003/006/"))
+			gz.Close()
+		case "/api/v2/assets/findings/T0Broken/title.md":
+			writeTestJSON(w, http.StatusInternalServerError, `{"errors": [{"message": "asset store unavailable"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	metadata, err := client.GetFindingMetadata("T0DCSync")
+	if err != nil {
+		t.Fatalf("GetFindingMetadata returned an error: %v", err)
+	}
+	if metadata.Title != "DCSync Privileges" || metadata.References != "- https://attack.mitre.org/techniques/T1003/006/" || metadata.LongDescription != "" {
+		t.Errorf("unexpected metadata: %+v", metadata)
+	}
+
+	if _, err := client.GetFindingMetadata("T0Unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown finding type, got %v", err)
+	}
+
+	_, err = client.GetFindingMetadata("T0Broken")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || !strings.Contains(err.Error(), "asset store unavailable") {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
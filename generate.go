@@ -0,0 +1,11 @@
+package bloodhound
+
+// The low-level Raw* methods and API* models in zz_generated_api.go are generated from
+// openapi/openapi.json, the vendored copy of the official BloodHound OpenAPI document
+// (packages/go/openapi/doc/openapi.json in the BloodHound repository). go generate
+// downloads the current document over it before generating, so it needs network access.
+// To check the hand-written client against the document, run
+// go run ./cmd/bhgen -spec openapi/openapi.json -coverage; it fails when the client calls
+// an endpoint the document does not have.
+
+//go:generate go run ./cmd/bhgen -fetch -spec openapi/openapi.json -out zz_generated_api.go
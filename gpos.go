@@ -1,28 +1,16 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // GetGPO fetches a single GPO by its Object ID (SID).
 func (c *Client) GetGPO(objectID string) (*GPO, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/gpos/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get gpo failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -32,8 +20,8 @@ func (c *Client) GetGPO(objectID string) (*GPO, error) {
 			Users     int `json:"users"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get gpo response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
 	}
 	gpo := response.Data.Props
 	gpo.ObjectType = "GPO"
@@ -45,16 +33,11 @@ func (c *Client) GetGPO(objectID string) (*GPO, error) {
 
 // GetGPOByName fetches a single GPO by its name.
 func (c *Client) GetGPOByName(gpoName string) (*GPO, error) {
-	searchResponse, err := c.Search(gpoName, "GPO", 1)
+	objectID, err := c.resolveObjectID("GPO", gpoName)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(searchResponse.Data) == 0 {
-		return nil, fmt.Errorf("gpo not found: %s", gpoName)
-	}
-
-	return c.GetGPO(searchResponse.Data[0].ObjectID)
+	return c.GetGPO(objectID)
 }
 
 // GetGPOControllers fetches the controllers of a given GPO.
@@ -66,19 +49,8 @@ func (c *Client) GetGPOControllers(objectID string, limit int) (ControllersRespo
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGPOAppliedOUs fetches the OUs a given GPO is applied to.
@@ -90,19 +62,8 @@ func (c *Client) GetGPOAppliedOUs(objectID string, limit int) (OUsResponse, erro
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGPOAppliedUsers fetches the users a given GPO is applied to.
@@ -114,19 +75,8 @@ func (c *Client) GetGPOAppliedUsers(objectID string, limit int) (UsersResponse,
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGPOAppliedComputers fetches the computers a given GPO is applied to.
@@ -138,17 +88,78 @@ func (c *Client) GetGPOAppliedComputers(objectID string, limit int) (ComputersRe
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetGPOTierZero fetches the Tier Zero objects affected by a given GPO.
+func (c *Client) GetGPOTierZero(objectID string, limit int) (ControllablesResponse, error) {
+	var rawResponse ControllablesResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/gpos/", objectID, "/tier-zero")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
-	resp, err := c.do(req, nil)
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// InheritedGPO is a GPO that applies to an object, either through a direct link or by inheritance.
+type InheritedGPO struct {
+	GPO      GraphNodeProperties
+	LinkedTo GraphNodeProperties
+	Enforced bool
+	Depth    int // 0 when the GPO is linked directly to the object
+}
+
+// GetGPOInheritance computes the GPOs that apply to an OU, container or other object
+// contained in a domain. It walks the containment chain up to the domain and honors
+// blocked inheritance and enforced links. Results are ordered from the closest link outwards.
+func (c *Client) GetGPOInheritance(objectID string) ([]InheritedGPO, error) {
+	query := fmt.Sprintf(`MATCH p=(:GPO)-[:GPLink]->()-[:Contains*0..]->(n) WHERE n.objectid = %s RETURN p`, cypherString(objectID))
+	graph, err := c.RunCypherGraph(context.Background(), query)
 	if err != nil {
-		return rawResponse, err
+		return nil, err
 	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
+
+	var targetID string
+	for id, node := range graph.Nodes {
+		if strings.EqualFold(node.ObjectID, objectID) {
+			targetID = id
+		}
+	}
+	if targetID == "" {
+		return []InheritedGPO{}, nil
 	}
-	return rawResponse, nil
+
+	parents := map[string]string{}
+	links := map[string][]GraphEdge{}
+	for _, edge := range graph.Edges {
+		switch edge.Kind {
+		case "Contains":
+			parents[edge.Target] = edge.Source
+		case "GPLink":
+			links[edge.Target] = append(links[edge.Target], edge)
+		}
+	}
+
+	inherited := []InheritedGPO{}
+	blocked := false
+	seen := map[string]bool{}
+	for depth, id := 0, targetID; id != "" && !seen[id]; depth, id = depth+1, parents[id] {
+		seen[id] = true
+		node := graph.Nodes[id]
+		for _, link := range links[id] {
+			enforced, _ := link.Properties["enforced"].(bool)
+			if blocked && !enforced {
+				continue
+			}
+			inherited = append(inherited, InheritedGPO{GPO: graph.Nodes[link.Source], LinkedTo: node, Enforced: enforced, Depth: depth})
+		}
+		if blocks, _ := node.Properties["blocksinheritance"].(bool); blocks {
+			blocked = true
+		}
+	}
+	return inherited, nil
 }
@@ -0,0 +1,42 @@
+package bloodhound
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetGPOInheritance(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", cypherHandler(t, map[string]string{
+		`"OU-LINKED"`: `{
+			"nodes": {
+				"1": {"label": "DEFAULT DOMAIN POLICY@CORP.LOCAL", "kind": "GPO", "objectId": "GPO-1"},
+				"2": {"label": "SERVERS POLICY@CORP.LOCAL", "kind": "GPO", "objectId": "GPO-2"},
+				"3": {"label": "CORP.LOCAL", "kind": "Domain", "objectId": "S-1-5-21-1"},
+				"4": {"label": "SERVERS@CORP.LOCAL", "kind": "OU", "objectId": "OU-LINKED", "properties": {"blocksinheritance": true}}
+			},
+			"edges": [
+				{"source": "1", "target": "3", "label": "GPLink", "kind": "GPLink", "properties": {"enforced": false}},
+				{"source": "2", "target": "4", "label": "GPLink", "kind": "GPLink", "properties": {"enforced": false}},
+				{"source": "3", "target": "4", "label": "Contains", "kind": "Contains"}
+			]
+		}`,
+	}))
+	client := newTestClient(t, mux)
+
+	inherited, err := client.GetGPOInheritance("OU-LINKED")
+	if err != nil {
+		t.Fatalf("GetGPOInheritance returned an error: %v", err)
+	}
+	if len(inherited) != 1 || inherited[0].GPO.ObjectID != "GPO-2" || inherited[0].Depth != 0 {
+		t.Errorf("expected only the directly linked GPO past blocked inheritance, got %+v", inherited)
+	}
+
+	inherited, err = client.GetGPOInheritance("OU-UNLINKED")
+	if err != nil {
+		t.Fatalf("GetGPOInheritance without links returned an error: %v", err)
+	}
+	if inherited == nil || len(inherited) != 0 {
+		t.Errorf("expected an empty, non-nil slice without links, got %#v", inherited)
+	}
+}
@@ -1,14 +1,20 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 )
 
-// GetShortestPath finds the shortest path between two nodes.
+// GetShortestPath finds the shortest path between two nodes. When no path exists the
+// error wraps ErrNotFound.
 func (c *Client) GetShortestPath(startNode, endNode, relationshipKinds string) (*ShortestPathResponse, error) {
+	return c.getShortestPath(context.Background(), startNode, endNode, relationshipKinds)
+}
+
+func (c *Client) getShortestPath(ctx context.Context, startNode, endNode, relationshipKinds string) (*ShortestPathResponse, error) {
 	params := url.Values{}
 	params.Add("start_node", startNode)
 	params.Add("end_node", endNode)
@@ -17,38 +23,19 @@ func (c *Client) GetShortestPath(startNode, endNode, relationshipKinds string) (
 	shortestPathURL := c.baseURL.JoinPath("/api/v2/graphs/shortest-path")
 	shortestPathURL.RawQuery = params.Encode()
 
-	req, err := c.newAuthenticatedRequest(http.MethodGet, shortestPathURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create shortest path request: %w", err)
+	var shortestPathResponse ShortestPathResponse
+	err := c.doJSON(ctx, http.MethodGet, shortestPathURL, nil, &shortestPathResponse)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("path %w", ErrNotFound)
 	}
-
-	resp, err := c.do(req, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute shortest path request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusNotFound {
-			var errorResponse ErrorResponse
-			if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err == nil {
-				if len(errorResponse.Errors) > 0 && errorResponse.Errors[0].Message == "Path not found" {
-					return nil, fmt.Errorf("path not found")
-				}
-			}
-		}
-		return nil, fmt.Errorf("shortest path request failed with status code: %d", resp.StatusCode)
-	}
-
-	var shortestPathResponse ShortestPathResponse
-	if err := json.NewDecoder(resp.Body).Decode(&shortestPathResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode shortest path response: %w", err)
+		return nil, err
 	}
-
 	return &shortestPathResponse, nil
 }
 
-// GetPathComposition returns the composition of a complex edge.
+// GetPathComposition returns the composition of a complex edge. See GetEdgeComposition
+// for the constituent nodes and edges in order.
 func (c *Client) GetPathComposition(startNode, endNode, edgeType string) (*ShortestPathResponse, error) {
 	params := url.Values{}
 	params.Add("source_node", startNode)
@@ -58,25 +45,9 @@ func (c *Client) GetPathComposition(startNode, endNode, edgeType string) (*Short
 	compositionURL := c.baseURL.JoinPath("/api/v2/graphs/edge-composition")
 	compositionURL.RawQuery = params.Encode()
 
-	req, err := c.newAuthenticatedRequest(http.MethodGet, compositionURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create path composition request: %w", err)
-	}
-
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute path composition request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("path composition request failed with status code: %d", resp.StatusCode)
-	}
-
 	var compositionResponse ShortestPathResponse
-	if err := json.NewDecoder(resp.Body).Decode(&compositionResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode path composition response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, compositionURL, nil, &compositionResponse); err != nil {
+		return nil, err
 	}
-
 	return &compositionResponse, nil
 }
@@ -1,29 +1,15 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 )
 
 // GetGroup fetches a single group by its Object ID (SID).
 func (c *Client) GetGroup(objectID string) (*Group, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/groups/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get group failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -38,8 +24,8 @@ func (c *Client) GetGroup(objectID string) (*Group, error) {
 			Sessions       int   `json:"sessions"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get group response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
 	}
 	group := response.Data.Props
 	group.ObjectType = "Group"
@@ -56,30 +42,11 @@ func (c *Client) GetGroup(objectID string) (*Group, error) {
 
 // GetGroupByName fetches a single group by its name.
 func (c *Client) GetGroupByName(groupName string) (*Group, error) {
-	searchResponse, err := c.Search(groupName, "Group", 0)
+	objectID, err := c.resolveObjectID("Group", groupName)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(searchResponse.Data) == 0 && strings.Contains(groupName, "@") {
-		samAccountName := strings.Split(groupName, "@")[0]
-		searchResponse, err = c.Search(samAccountName, "Group", 0)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, groupName) || (strings.Contains(groupName, "@") && strings.EqualFold(result.Name, strings.Split(groupName, "@")[0])) {
-			return c.GetGroup(result.ObjectID)
-		}
-	}
-
-	if len(searchResponse.Data) == 1 {
-		return c.GetGroup(searchResponse.Data[0].ObjectID)
-	}
-
-	return nil, fmt.Errorf("group not found: %s", groupName)
+	return c.GetGroup(objectID)
 }
 
 // GetGroupMembers fetches the members of a given group.
@@ -91,19 +58,8 @@ func (c *Client) GetGroupMembers(objectID string, limit int) (GroupMembershipsRe
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupMemberships fetches the group memberships for a given group.
@@ -115,19 +71,8 @@ func (c *Client) GetGroupMemberships(objectID string, limit int) (GroupMembershi
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupControllers fetches the controllers of a given group.
@@ -139,19 +84,8 @@ func (c *Client) GetGroupControllers(objectID string, limit int) (ControllersRes
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupControllables fetches the controllables of a given group.
@@ -163,19 +97,8 @@ func (c *Client) GetGroupControllables(objectID string, limit int) (Controllable
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupDCOMRights fetches principals with DCOM rights on the group.
@@ -187,19 +110,8 @@ func (c *Client) GetGroupDCOMRights(objectID string, limit int) (PrivilegesRespo
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupPSRemoteRights fetches principals with PSRemote rights on the group.
@@ -211,19 +123,8 @@ func (c *Client) GetGroupPSRemoteRights(objectID string, limit int) (PrivilegesR
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupRDPRights fetches principals with RDP rights on the group.
@@ -235,19 +136,8 @@ func (c *Client) GetGroupRDPRights(objectID string, limit int) (PrivilegesRespon
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetGroupSessions fetches sessions on the group.
@@ -259,17 +149,19 @@ func (c *Client) GetGroupSessions(objectID string, limit int) (SessionsResponse,
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetGroupAdminRights fetches the entities a given group has admin rights to.
+func (c *Client) GetGroupAdminRights(objectID string, limit int) (EntityAdminsResponse, error) {
+	var rawResponse EntityAdminsResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/groups/", objectID, "/admin-rights")
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
-	return rawResponse, nil
+	apiUrl.RawQuery = params.Encode()
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
@@ -0,0 +1,233 @@
+package bloodhound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultHydrationConcurrency is the number of concurrent fetches used when none is set.
+const DefaultHydrationConcurrency = 8
+
+// HydrationOptions tunes HydrateEntitiesWithOptions.
+type HydrationOptions struct {
+	// Concurrency bounds the number of entities fetched at once. Zero uses DefaultHydrationConcurrency.
+	Concurrency int
+	// SkipCypher disables the single Cypher query tried before fetching entities one by one.
+	SkipCypher bool
+}
+
+// HydrationResult holds the entities fetched by HydrateEntities, keyed by upper-case
+// Object ID, along with the error for every ID that could not be fetched.
+type HydrationResult struct {
+	Entities map[string]Entity
+	Errors   map[string]error
+}
+
+// Get returns the entity for an Object ID, if it was fetched.
+func (r *HydrationResult) Get(objectID string) (Entity, bool) {
+	e, ok := r.Entities[strings.ToUpper(objectID)]
+	return e, ok
+}
+
+// Err joins the per-ID errors, or returns nil when every ID was fetched.
+func (r *HydrationResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(r.Errors))
+	for id := range r.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs, fmt.Errorf("%s: %w", id, r.Errors[id]))
+	}
+	return errors.Join(errs...)
+}
+
+// HydrateEntities fetches the full entity for every Object ID, e.g. to enrich the names
+// and IDs returned by the controllers or members endpoints. See HydrateEntitiesWithOptions.
+func (c *Client) HydrateEntities(ctx context.Context, objectIDs []string) *HydrationResult {
+	return c.HydrateEntitiesWithOptions(ctx, objectIDs, HydrationOptions{})
+}
+
+// HydrateEntitiesWithOptions fetches the full entity for every Object ID. Duplicate IDs are
+// fetched once. All IDs are first looked up with Cypher, in batches; IDs the queries do not
+// return, including those of the batches left when Cypher fails, are fetched with GetEntity
+// by a bounded pool of workers. Nodes whose properties cannot be decoded are recorded in
+// Errors. Concurrent fetches of the same ID share a single request.
+func (c *Client) HydrateEntitiesWithOptions(ctx context.Context, objectIDs []string, opts HydrationOptions) *HydrationResult {
+	result := &HydrationResult{Entities: map[string]Entity{}, Errors: map[string]error{}}
+
+	var pending []string
+	seen := map[string]bool{}
+	for _, objectID := range objectIDs {
+		key := strings.ToUpper(objectID)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			pending = append(pending, key)
+		}
+	}
+	if len(pending) == 0 {
+		return result
+	}
+
+	if !opts.SkipCypher {
+		// A failed batch leaves its IDs, and those of the later batches, to GetEntity.
+		entities, decodeErrs, _ := c.hydrateWithCypher(ctx, pending)
+		remaining := pending[:0]
+		for _, key := range pending {
+			if e, ok := entities[key]; ok {
+				result.Entities[key] = e
+			} else if err, ok := decodeErrs[key]; ok {
+				result.Errors[key] = err
+			} else {
+				remaining = append(remaining, key)
+			}
+		}
+		pending = remaining
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultHydrationConcurrency
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan string)
+	)
+	for i := 0; i < concurrency && i < len(pending); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				value, err := c.entityFetches.Do(ctx, key, func(ctx context.Context) (interface{}, error) {
+					return c.GetEntity(ctx, key)
+				})
+				mu.Lock()
+				if err != nil {
+					result.Errors[key] = err
+				} else {
+					result.Entities[key] = value.(Entity)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, key := range pending {
+		if ctx.Err() != nil {
+			mu.Lock()
+			result.Errors[key] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+	return result
+}
+
+// hydrateWithCypherBatch bounds the number of IDs inlined in a single Cypher query.
+const hydrateWithCypherBatch = 500
+
+// hydrateWithCypher fetches the given nodes with Cypher, in batches, keyed by upper-case Object ID.
+// Nodes whose properties cannot be decoded are returned in decodeErrs. When a batch fails, the
+// entities of the previous batches are returned along with the error.
+func (c *Client) hydrateWithCypher(ctx context.Context, keys []string) (entities map[string]Entity, decodeErrs map[string]error, err error) {
+	entities, decodeErrs = map[string]Entity{}, map[string]error{}
+	for start := 0; start < len(keys); start += hydrateWithCypherBatch {
+		end := start + hydrateWithCypherBatch
+		if end > len(keys) {
+			end = len(keys)
+		}
+		quoted := make([]string, 0, end-start)
+		for _, key := range keys[start:end] {
+			quoted = append(quoted, cypherString(key))
+		}
+		query := fmt.Sprintf("MATCH (n) WHERE n.objectid IN [%s] RETURN n", strings.Join(quoted, ", "))
+
+		graph, err := c.RunCypherGraph(ctx, query)
+		if err != nil {
+			return entities, decodeErrs, err
+		}
+		for _, node := range graph.Nodes {
+			if node.ObjectID == "" || node.Properties == nil {
+				continue
+			}
+			key := strings.ToUpper(node.ObjectID)
+			e, err := newEntity(node.Kind, node.ObjectID, node.Properties)
+			if err != nil {
+				decodeErrs[key] = err
+				continue
+			}
+			entities[key] = e
+		}
+	}
+	return entities, decodeErrs, nil
+}
+
+// flightGroup deduplicates concurrent calls that share a key: while a call for a key is
+// in flight, later callers wait for it and receive its result. The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// Do runs fn with ctx for key unless a call for key is already in flight, in which case it
+// waits for that call or for ctx to be done. The in-flight call runs with the context of
+// the caller that started it, so a waiter whose own ctx is still live does not take a
+// context error from it, and runs fn again instead.
+func (g *flightGroup) Do(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = map[string]*flightCall{}
+		}
+		call, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if isContextError(call.err) && ctx.Err() == nil {
+			continue
+		}
+		return call.value, call.err
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.value, call.err = fn(ctx)
+
+	// The call is removed before its waiters are released, so a waiter that retries starts a new call.
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// isContextError reports whether err is the result of a cancelled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
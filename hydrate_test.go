@@ -0,0 +1,258 @@
+package bloodhound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// hydrationServer serves the nodes with the given Object IDs through Cypher and the base
+// entity endpoint, counting the requests each one receives. With cypherDown set, Cypher
+// answers every query with a server error, and with cypherFailAfter set every query after
+// that many. Nodes in malformed have properties that do not decode.
+type hydrationServer struct {
+	objectIDs       map[string]bool
+	malformed       map[string]bool
+	cypherDown      bool
+	cypherFailAfter int32
+	release         chan struct{}
+	entered         chan struct{}
+	cypherQueries   atomic.Int32
+	entityFetches   atomic.Int32
+}
+
+var cypherObjectIDs = regexp.MustCompile(`"([^"]+)"`)
+
+func (s *hydrationServer) client(t *testing.T) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", func(w http.ResponseWriter, r *http.Request) {
+		queries := s.cypherQueries.Add(1)
+		if s.cypherDown || (s.cypherFailAfter > 0 && queries > s.cypherFailAfter) {
+			writeTestJSON(w, http.StatusInternalServerError, `{"errors": [{"message": "cypher is disabled"}]}`)
+			return
+		}
+		var query CypherQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			t.Errorf("failed to decode cypher request: %v", err)
+		}
+		nodes := map[string]GraphNodeProperties{}
+		for _, match := range cypherObjectIDs.FindAllStringSubmatch(query.Query, -1) {
+			if id := match[1]; s.objectIDs[id] {
+				properties := map[string]interface{}{"objectid": id, "name": id}
+				if s.malformed[id] {
+					properties["enabled"] = "yes"
+				}
+				nodes[id] = GraphNodeProperties{Name: id, Kind: "User", ObjectID: id, Properties: properties}
+			}
+		}
+		if len(nodes) == 0 {
+			writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+			return
+		}
+		data, _ := json.Marshal(CypherResponseData{Nodes: nodes, Edges: []GraphEdge{}})
+		writeTestJSON(w, http.StatusOK, `{"data": `+string(data)+`}`)
+	})
+	mux.HandleFunc("/api/v2/base/", func(w http.ResponseWriter, r *http.Request) {
+		s.entityFetches.Add(1)
+		if s.entered != nil {
+			s.entered <- struct{}{}
+			<-s.release
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/base/")
+		if !s.objectIDs[id] {
+			writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+			return
+		}
+		writeTestJSON(w, http.StatusOK, fmt.Sprintf(`{"data": {"props": {"objectid": %q, "name": %q}}}`, id, id))
+	})
+	mux.HandleFunc("/api/v2/azure/entities/", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+	})
+	return newTestClient(t, mux)
+}
+
+func TestHydrateEntities(t *testing.T) {
+	ids := make([]string, 0, hydrateWithCypherBatch+2)
+	known := map[string]bool{}
+	for i := 0; i < hydrateWithCypherBatch+1; i++ {
+		id := fmt.Sprintf("S-1-5-21-1-%d", 1000+i)
+		ids = append(ids, id)
+		known[id] = true
+	}
+	ids = append(ids, strings.ToLower(ids[0]), "S-1-5-21-1-9999")
+
+	tests := []struct {
+		name            string
+		cypherDown      bool
+		cypherFailAfter int32
+		opts            HydrationOptions
+		cypherQueries   int32
+		entityFetches   int32
+	}{
+		{"cypher batches", false, 0, HydrationOptions{}, 2, 1},
+		{"cypher unavailable", true, 0, HydrationOptions{Concurrency: 4}, 1 + hydrateWithCypherBatch + 1, hydrateWithCypherBatch + 2},
+		{"cypher skipped", false, 0, HydrationOptions{SkipCypher: true}, hydrateWithCypherBatch + 1, hydrateWithCypherBatch + 2},
+		// The first batch is kept; only the IDs of the failed second batch are fetched, and the
+		// known one looks up its kind with one more Cypher query.
+		{"cypher fails on the second batch", false, 1, HydrationOptions{}, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &hydrationServer{objectIDs: known, cypherDown: tt.cypherDown, cypherFailAfter: tt.cypherFailAfter}
+			result := server.client(t).HydrateEntitiesWithOptions(context.Background(), ids, tt.opts)
+
+			if len(result.Entities) != len(known) {
+				t.Errorf("got %d entities, want %d", len(result.Entities), len(known))
+			}
+			if e, ok := result.Get(strings.ToLower(ids[1])); !ok || e.Name() != ids[1] {
+				t.Errorf("Get(%s) = %v, %v", ids[1], e, ok)
+			}
+			if len(result.Errors) != 1 || !errors.Is(result.Errors["S-1-5-21-1-9999"], ErrNotFound) {
+				t.Errorf("expected only the unknown ID to fail with ErrNotFound, got %v", result.Errors)
+			}
+			if got := server.cypherQueries.Load(); got != tt.cypherQueries {
+				t.Errorf("got %d Cypher queries, want %d", got, tt.cypherQueries)
+			}
+			if got := server.entityFetches.Load(); got != tt.entityFetches {
+				t.Errorf("got %d entity fetches, want %d", got, tt.entityFetches)
+			}
+		})
+	}
+}
+
+func TestHydrateEntitiesSharesFetches(t *testing.T) {
+	const callers = 5
+	server := &hydrationServer{
+		objectIDs: map[string]bool{"S-1-5-21-1-1104": true},
+		entered:   make(chan struct{}, callers),
+		release:   make(chan struct{}),
+	}
+	client := server.client(t)
+	hydrate := func() *HydrationResult {
+		return client.HydrateEntitiesWithOptions(context.Background(), []string{"S-1-5-21-1-1104"}, HydrationOptions{SkipCypher: true})
+	}
+
+	results := make([]*HydrationResult, callers)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0] = hydrate()
+	}()
+	<-server.entered
+
+	var started sync.WaitGroup
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		started.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			results[i] = hydrate()
+		}()
+	}
+	started.Wait()
+	// Give the other callers time to join the fetch in flight before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(server.release)
+	wg.Wait()
+
+	if got := server.entityFetches.Load(); got != 1 {
+		t.Errorf("got %d entity fetches for %d concurrent callers, want 1", got, callers)
+	}
+	for i, result := range results {
+		if e, ok := result.Get("S-1-5-21-1-1104"); !ok || e.Name() != "S-1-5-21-1-1104" || result.Err() != nil {
+			t.Errorf("caller %d got %v, %v (err %v)", i, e, ok, result.Err())
+		}
+	}
+}
+
+func TestHydrateEntitiesCancelled(t *testing.T) {
+	server := &hydrationServer{objectIDs: map[string]bool{"S-1-5-21-1-1104": true, "S-1-5-21-1-1105": true}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := server.client(t).HydrateEntities(ctx, []string{"S-1-5-21-1-1104", "S-1-5-21-1-1105"})
+	if len(result.Entities) != 0 || len(result.Errors) != 2 || !errors.Is(result.Err(), context.Canceled) {
+		t.Errorf("expected every ID to fail with context.Canceled, got %v", result.Err())
+	}
+	if got := server.cypherQueries.Load() + server.entityFetches.Load(); got != 0 {
+		t.Errorf("got %d requests after cancellation, want 0", got)
+	}
+}
+
+func TestHydrateEntitiesDecodeErrors(t *testing.T) {
+	server := &hydrationServer{
+		objectIDs: map[string]bool{"S-1-5-21-1-1104": true, "S-1-5-21-1-1105": true},
+		malformed: map[string]bool{"S-1-5-21-1-1105": true},
+	}
+	result := server.client(t).HydrateEntities(context.Background(), []string{"S-1-5-21-1-1104", "S-1-5-21-1-1105"})
+	if _, ok := result.Get("S-1-5-21-1-1104"); !ok || len(result.Entities) != 1 {
+		t.Errorf("expected only the well-formed entity, got %v", result.Entities)
+	}
+	if err := result.Errors["S-1-5-21-1-1105"]; err == nil || !strings.Contains(err.Error(), "failed to decode User properties") {
+		t.Errorf("expected a decode error for the malformed entity, got %v", result.Errors)
+	}
+	if got := server.entityFetches.Load(); got != 0 {
+		t.Errorf("got %d entity fetches, want 0", got)
+	}
+}
+
+func TestFlightGroupContexts(t *testing.T) {
+	var g flightGroup
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	entered, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	fetch := func(ctx context.Context) (interface{}, error) {
+		if calls.Add(1) == 1 {
+			close(entered)
+			<-release
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return "value", nil
+	}
+
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := g.Do(leaderCtx, "key", fetch)
+		leaderErr <- err
+	}()
+	<-entered
+
+	// A waiter whose context ends stops waiting without affecting the call in flight.
+	waiterCtx, cancelWaiter := context.WithCancel(context.Background())
+	cancelWaiter()
+	if _, err := g.Do(waiterCtx, "key", fetch); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled waiter got %v, want context.Canceled", err)
+	}
+
+	// A live waiter does not take the leader's cancellation, and fetches again instead.
+	waiterResult := make(chan interface{}, 1)
+	go func() {
+		value, err := g.Do(context.Background(), "key", fetch)
+		if err != nil {
+			t.Errorf("live waiter got %v", err)
+		}
+		waiterResult <- value
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancelLeader()
+	close(release)
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader got %v, want context.Canceled", err)
+	}
+	if value := <-waiterResult; value != "value" || calls.Load() != 2 {
+		t.Errorf("live waiter got %v after %d calls, want value after 2", value, calls.Load())
+	}
+}
@@ -0,0 +1,203 @@
+package bloodhound
+
+import "strings"
+
+// Platform is the part of the graph a kind belongs to.
+type Platform string
+
+// Platforms of node and edge kinds. Kinds this package does not know, such as those added
+// through generic ingest, are PlatformGeneric.
+const (
+	PlatformAD      Platform = "AD"
+	PlatformAzure   Platform = "Azure"
+	PlatformGeneric Platform = "Generic"
+)
+
+// Platform returns the platform of the node kind.
+func (k NodeKind) Platform() Platform {
+	switch {
+	case k == KindBase:
+		return PlatformAD
+	case k == KindAZBase:
+		return PlatformAzure
+	}
+	for _, kind := range ADNodeKinds {
+		if kind == k {
+			return PlatformAD
+		}
+	}
+	for _, kind := range AzureNodeKinds {
+		if kind == k {
+			return PlatformAzure
+		}
+	}
+	return PlatformGeneric
+}
+
+// EdgeKindInfo describes how BloodHound treats an edge kind.
+type EdgeKindInfo struct {
+	Kind     EdgeKind
+	Platform Platform
+	// Traversable edges are followed by pathfinding; the others only describe the graph,
+	// e.g. GetChanges, which is traversed as part of DCSync.
+	Traversable bool
+	// PostProcessed edges are created by BloodHound from other edges and properties
+	// rather than collected, e.g. AdminTo, DCSync and the ADCS escalation edges.
+	PostProcessed bool
+	// ACL edges are derived from an access control entry on the target object.
+	ACL bool
+}
+
+var nonTraversableEdgeKinds = []EdgeKind{
+	EdgeGetChanges, EdgeGetChangesAll, EdgeGetChangesInFilteredSet, EdgeLocalToComputer,
+	EdgeMemberOfLocalGroup, EdgeRemoteInteractiveLogonRight, EdgeRootCAFor, EdgePublishedTo,
+	EdgeManageCertificates, EdgeManageCA, EdgeDelegatedEnrollmentAgent, EdgeEnroll,
+	EdgeHostsCAService, EdgeWritePKIEnrollmentFlag, EdgeWritePKINameFlag, EdgeNTAuthStoreFor,
+	EdgeTrustedForNTAuth, EdgeEnterpriseCAFor, EdgeIssuedSignedBy, EdgeEnrollOnBehalfOf,
+	EdgeOIDGroupLink, EdgeExtendedByPolicy, EdgeCrossForestTrust, EdgeWriteOwnerRaw, EdgeOwnsRaw,
+	EdgeProtectAdminGroups, EdgeWriteAltSecurityIdentities, EdgeWritePublicInformation,
+	EdgeCanAbuseUPNCertMapping, EdgeCanAbuseWeakCertBinding,
+	EdgeAZScopedTo, EdgeAZMGApplicationReadWriteAll, EdgeAZMGAppRoleAssignmentReadWriteAll,
+	EdgeAZMGDirectoryReadWriteAll, EdgeAZMGGroupReadWriteAll, EdgeAZMGGroupMemberReadWriteAll,
+	EdgeAZMGRoleManagementReadWriteDirectory, EdgeAZMGServicePrincipalEndpointReadWriteAll,
+}
+
+var postProcessedEdgeKinds = append([]EdgeKind{
+	EdgeAdminTo, EdgeCanRDP, EdgeCanPSRemote, EdgeExecuteDCOM, EdgeDCSync, EdgeSyncLAPSPassword,
+	EdgeSyncedToEntraUser, EdgeSyncedToADUser, EdgeWriteOwnerLimitedRights, EdgeOwnsLimitedRights,
+	EdgeSpoofSIDHistory, EdgeAbuseTGTDelegation, EdgeHasTrustKeys, EdgeProtectAdminGroups,
+	EdgeClaimSpecialIdentity, EdgeContainsIdentity, EdgePropagatesACEsTo, EdgeGPOAppliesTo,
+	EdgeCanApplyGPO, EdgeCanAbuseUPNCertMapping, EdgeCanAbuseWeakCertBinding,
+	EdgeAZAddMembers, EdgeAZAddSecret, EdgeAZExecuteCommand, EdgeAZGlobalAdmin,
+	EdgeAZPrivilegedAuthAdmin, EdgeAZPrivilegedRoleAdmin, EdgeAZResetPassword, EdgeAZAddOwner,
+	EdgeAZMGAddMember, EdgeAZMGAddOwner, EdgeAZMGAddSecret, EdgeAZMGGrantAppRoles, EdgeAZMGGrantRole,
+}, CompositeEdgeKinds...)
+
+var aclEdgeKinds = []EdgeKind{
+	EdgeOwns, EdgeGenericAll, EdgeGenericWrite, EdgeWriteOwner, EdgeWriteDacl,
+	EdgeForceChangePassword, EdgeAllExtendedRights, EdgeAddMember, EdgeAddSelf,
+	EdgeAddAllowedToAct, EdgeWriteSPN, EdgeAddKeyCredentialLink, EdgeWriteAccountRestrictions,
+	EdgeWriteGPLink, EdgeReadLAPSPassword, EdgeReadGMSAPassword, EdgeGetChanges,
+	EdgeGetChangesAll, EdgeGetChangesInFilteredSet, EdgeManageCA, EdgeManageCertificates,
+	EdgeEnroll, EdgeWritePKIEnrollmentFlag, EdgeWritePKINameFlag, EdgeWriteOwnerLimitedRights,
+	EdgeOwnsLimitedRights, EdgeWriteOwnerRaw, EdgeOwnsRaw, EdgeWriteAltSecurityIdentities,
+	EdgeWritePublicInformation,
+}
+
+// edgeKindInfos holds the metadata of every known edge kind.
+var edgeKindInfos = func() map[EdgeKind]EdgeKindInfo {
+	infos := map[EdgeKind]EdgeKindInfo{}
+	for _, kind := range ADEdgeKinds {
+		infos[kind] = EdgeKindInfo{Kind: kind, Platform: PlatformAD, Traversable: true}
+	}
+	for _, kind := range AzureEdgeKinds {
+		infos[kind] = EdgeKindInfo{Kind: kind, Platform: PlatformAzure, Traversable: true}
+	}
+	update := func(kinds []EdgeKind, set func(*EdgeKindInfo)) {
+		for _, kind := range kinds {
+			info := infos[kind]
+			set(&info)
+			infos[kind] = info
+		}
+	}
+	update(nonTraversableEdgeKinds, func(info *EdgeKindInfo) { info.Traversable = false })
+	update(postProcessedEdgeKinds, func(info *EdgeKindInfo) { info.PostProcessed = true })
+	update(aclEdgeKinds, func(info *EdgeKindInfo) { info.ACL = true })
+	return infos
+}()
+
+// Info returns the metadata of the edge kind. Unknown kinds are reported as traversable
+// generic edges.
+func (k EdgeKind) Info() EdgeKindInfo {
+	if info, ok := edgeKindInfos[k]; ok {
+		return info
+	}
+	return EdgeKindInfo{Kind: k, Platform: PlatformGeneric, Traversable: true}
+}
+
+// Platform returns the platform of the edge kind.
+func (k EdgeKind) Platform() Platform { return k.Info().Platform }
+
+// IsTraversable reports whether pathfinding follows edges of the kind.
+func (k EdgeKind) IsTraversable() bool { return k.Info().Traversable }
+
+// IsPostProcessed reports whether edges of the kind are created by post-processing.
+func (k EdgeKind) IsPostProcessed() bool { return k.Info().PostProcessed }
+
+// IsACL reports whether edges of the kind are derived from an access control entry.
+func (k EdgeKind) IsACL() bool { return k.Info().ACL }
+
+// EdgeKindsWhere returns the known AD and Azure edge kinds matching the predicate, in
+// declaration order.
+func EdgeKindsWhere(match func(EdgeKindInfo) bool) []EdgeKind {
+	var kinds []EdgeKind
+	for _, list := range [][]EdgeKind{ADEdgeKinds, AzureEdgeKinds} {
+		for _, kind := range list {
+			if match(edgeKindInfos[kind]) {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	return kinds
+}
+
+// TraversableEdgeKinds returns the traversable edge kinds of the given platforms, or of
+// every platform when none is given.
+func TraversableEdgeKinds(platforms ...Platform) []EdgeKind {
+	return EdgeKindsWhere(func(info EdgeKindInfo) bool {
+		return info.Traversable && platformMatches(info.Platform, platforms)
+	})
+}
+
+func platformMatches(platform Platform, platforms []Platform) bool {
+	if len(platforms) == 0 {
+		return true
+	}
+	for _, p := range platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// TraversableFilter returns a filter that follows only the traversable edge kinds of the
+// given platforms, or of every platform when none is given.
+func TraversableFilter(platforms ...Platform) PathFilter {
+	return OnlyEdges(TraversableEdgeKinds(platforms...)...)
+}
+
+// RelationshipKindsIn builds the relationship_kinds value of GetShortestPath that
+// traverses only the given kinds, e.g. "in:MemberOf,AdminTo".
+func RelationshipKindsIn(kinds ...EdgeKind) string {
+	return "in:" + joinEdgeKinds(kinds, ",")
+}
+
+// RelationshipKindsNotIn builds the relationship_kinds value of GetShortestPath that
+// traverses every kind except the given ones, e.g. "nin:HasSession".
+func RelationshipKindsNotIn(kinds ...EdgeKind) string {
+	return "nin:" + joinEdgeKinds(kinds, ",")
+}
+
+// ParseRelationshipKinds parses a relationship_kinds value back into a filter. An empty
+// value allows every kind.
+func ParseRelationshipKinds(value string) PathFilter {
+	var filter PathFilter
+	switch {
+	case strings.HasPrefix(value, "in:"):
+		filter.Include = splitEdgeKinds(strings.TrimPrefix(value, "in:"))
+	case strings.HasPrefix(value, "nin:"):
+		filter.Exclude = splitEdgeKinds(strings.TrimPrefix(value, "nin:"))
+	}
+	return filter
+}
+
+func splitEdgeKinds(value string) []EdgeKind {
+	var kinds []EdgeKind
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			kinds = append(kinds, EdgeKind(name))
+		}
+	}
+	return kinds
+}
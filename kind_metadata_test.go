@@ -0,0 +1,100 @@
+package bloodhound
+
+import "testing"
+
+func TestEdgeKindInfo(t *testing.T) {
+	tests := []struct {
+		kind EdgeKind
+		want EdgeKindInfo
+	}{
+		{EdgeGenericAll, EdgeKindInfo{Kind: EdgeGenericAll, Platform: PlatformAD, Traversable: true, ACL: true}},
+		{EdgeGetChanges, EdgeKindInfo{Kind: EdgeGetChanges, Platform: PlatformAD, ACL: true}},
+		{EdgeADCSESC1, EdgeKindInfo{Kind: EdgeADCSESC1, Platform: PlatformAD, Traversable: true, PostProcessed: true}},
+		{EdgeCrossForestTrust, EdgeKindInfo{Kind: EdgeCrossForestTrust, Platform: PlatformAD}},
+		{EdgeSpoofSIDHistory, EdgeKindInfo{Kind: EdgeSpoofSIDHistory, Platform: PlatformAD, Traversable: true, PostProcessed: true}},
+		{EdgeAZScopedTo, EdgeKindInfo{Kind: EdgeAZScopedTo, Platform: PlatformAzure}},
+		{EdgeAZMGGroupReadWriteAll, EdgeKindInfo{Kind: EdgeAZMGGroupReadWriteAll, Platform: PlatformAzure}},
+		{EdgeAZAddSecret, EdgeKindInfo{Kind: EdgeAZAddSecret, Platform: PlatformAzure, Traversable: true, PostProcessed: true}},
+		{"CustomEdge", EdgeKindInfo{Kind: "CustomEdge", Platform: PlatformGeneric, Traversable: true}},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.Info(); got != tt.want {
+			t.Errorf("%s.Info() = %+v, want %+v", tt.kind, got, tt.want)
+		}
+	}
+
+	if KindUser.Platform() != PlatformAD || KindAZVM.Platform() != PlatformAzure || NodeKind("Custom").Platform() != PlatformGeneric {
+		t.Error("unexpected node kind platforms")
+	}
+
+	for _, kind := range TraversableEdgeKinds(PlatformAzure) {
+		if kind.Platform() != PlatformAzure || !kind.IsTraversable() {
+			t.Errorf("TraversableEdgeKinds(Azure) returned %s", kind)
+		}
+	}
+
+	if got := RelationshipKindsIn(EdgeMemberOf, EdgeAdminTo); got != "in:MemberOf,AdminTo" {
+		t.Errorf("RelationshipKindsIn() = %q", got)
+	}
+	filter := ParseRelationshipKinds(RelationshipKindsNotIn(EdgeHasSession))
+	if filter.Allows(EdgeHasSession) || !filter.Allows(EdgeMemberOf) || filter.String() != "nin:HasSession" {
+		t.Errorf("unexpected parsed filter: %+v", filter)
+	}
+}
+
+// bloodHoundEdgeKinds are the relationship kinds of the BloodHound CE graph schema
+// (packages/go/graphschema), copied verbatim so that typos in the constants are caught.
+var bloodHoundEdgeKinds = map[Platform][]string{
+	PlatformAD: {
+		"Owns", "GenericAll", "GenericWrite", "WriteOwner", "WriteDacl", "MemberOf",
+		"ForceChangePassword", "AllExtendedRights", "AddMember", "HasSession", "Contains", "GPLink",
+		"AllowedToDelegate", "CoerceToTGT", "GetChanges", "GetChangesAll", "GetChangesInFilteredSet",
+		"CrossForestTrust", "SameForestTrust", "SpoofSIDHistory", "AbuseTGTDelegation", "AllowedToAct",
+		"AdminTo", "CanPSRemote", "CanRDP", "ExecuteDCOM", "HasSIDHistory", "AddSelf", "DCSync",
+		"ReadLAPSPassword", "ReadGMSAPassword", "DumpSMSAPassword", "SQLAdmin", "AddAllowedToAct",
+		"WriteSPN", "AddKeyCredentialLink", "LocalToComputer", "MemberOfLocalGroup",
+		"RemoteInteractiveLogonRight", "SyncLAPSPassword", "WriteAccountRestrictions", "WriteGPLink",
+		"RootCAFor", "DCFor", "PublishedTo", "ManageCertificates", "ManageCA",
+		"DelegatedEnrollmentAgent", "Enroll", "HostsCAService", "WritePKIEnrollmentFlag",
+		"WritePKINameFlag", "NTAuthStoreFor", "TrustedForNTAuth", "EnterpriseCAFor", "IssuedSignedBy",
+		"GoldenCert", "EnrollOnBehalfOf", "OIDGroupLink", "ExtendedByPolicy", "ADCSESC1", "ADCSESC3",
+		"ADCSESC4", "ADCSESC6a", "ADCSESC6b", "ADCSESC9a", "ADCSESC9b", "ADCSESC10a", "ADCSESC10b",
+		"ADCSESC13", "SyncedToEntraUser", "CoerceAndRelayNTLMToSMB", "CoerceAndRelayNTLMToADCS",
+		"CoerceAndRelayNTLMToLDAP", "CoerceAndRelayNTLMToLDAPS", "WriteOwnerLimitedRights",
+		"WriteOwnerRaw", "OwnsLimitedRights", "OwnsRaw", "ClaimSpecialIdentity", "ContainsIdentity",
+		"PropagatesACEsTo", "GPOAppliesTo", "CanApplyGPO", "HasTrustKeys", "ProtectAdminGroups",
+		"WriteAltSecurityIdentities", "WritePublicInformation", "CanAbuseUPNCertMapping",
+		"CanAbuseWeakCertBinding",
+	},
+	PlatformAzure: {
+		"AZAvereContributor", "AZContains", "AZContributor", "AZGetCertificates", "AZGetKeys",
+		"AZGetSecrets", "AZHasRole", "AZMemberOf", "AZOwner", "AZRunsAs", "AZVMContributor",
+		"AZAutomationContributor", "AZKeyVaultKVContributor", "AZVMAdminLogin", "AZAddMembers",
+		"AZAddSecret", "AZExecuteCommand", "AZGlobalAdmin", "AZPrivilegedAuthAdmin", "AZGrant",
+		"AZGrantSelf", "AZPrivilegedRoleAdmin", "AZResetPassword", "AZUserAccessAdministrator",
+		"AZOwns", "AZScopedTo", "AZCloudAppAdmin", "AZAppAdmin", "AZAddOwner", "AZManagedIdentity",
+		"AZMGApplication_ReadWrite_All", "AZMGAppRoleAssignment_ReadWrite_All",
+		"AZMGDirectory_ReadWrite_All", "AZMGGroup_ReadWrite_All", "AZMGGroupMember_ReadWrite_All",
+		"AZMGRoleManagement_ReadWrite_Directory", "AZMGServicePrincipalEndpoint_ReadWrite_All",
+		"AZAKSContributor", "AZNodeResourceGroup", "AZWebsiteContributor", "AZLogicAppContributor",
+		"AZMGAddMember", "AZMGAddOwner", "AZMGAddSecret", "AZMGGrantAppRoles", "AZMGGrantRole",
+		"SyncedToADUser", "AZRoleEligible", "AZRoleApprover",
+	},
+}
+
+func TestEdgeKindsMatchBloodHound(t *testing.T) {
+	for platform, names := range bloodHoundEdgeKinds {
+		known := map[EdgeKind]bool{}
+		for _, name := range names {
+			known[EdgeKind(name)] = true
+			if got := EdgeKind(name).Platform(); got != platform {
+				t.Errorf("BloodHound edge kind %s has platform %s, want %s", name, got, platform)
+			}
+		}
+		for _, kind := range EdgeKindsWhere(func(info EdgeKindInfo) bool { return info.Platform == platform }) {
+			if !known[kind] {
+				t.Errorf("%s edge kind %s is not a BloodHound edge kind", platform, kind)
+			}
+		}
+	}
+}
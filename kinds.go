@@ -0,0 +1,414 @@
+package bloodhound
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// System tags BloodHound assigns to nodes. They are also the tags of the matching asset groups.
+const (
+	TagTierZero = "admin_tier_0"
+	TagOwned    = "owned"
+)
+
+// Kinds BloodHound adds to the kind set of tagged nodes in newer versions.
+const (
+	KindTagTierZero = "Tag_Tier_Zero"
+	KindTagOwned    = "Tag_Owned"
+)
+
+// TagSet is the parsed form of the space-separated system_tags property.
+type TagSet []string
+
+func (ts *TagSet) UnmarshalJSON(b []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	switch value := raw.(type) {
+	case string:
+		*ts = strings.Fields(value)
+	case []interface{}:
+		tags := make(TagSet, 0, len(value))
+		for _, tag := range value {
+			if s, ok := tag.(string); ok && s != "" {
+				tags = append(tags, s)
+			}
+		}
+		*ts = tags
+	default:
+		*ts = nil
+	}
+	return nil
+}
+
+// MarshalJSON encodes the tags back to the space-separated form the API uses.
+func (ts TagSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ts.String())
+}
+
+func (ts TagSet) String() string {
+	return strings.Join(ts, " ")
+}
+
+// Has reports whether the set contains the tag. Tags are compared case-insensitively.
+func (ts TagSet) Has(tag string) bool {
+	for _, t := range ts {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTierZero reports whether the set contains the Tier Zero tag.
+func (ts TagSet) IsTierZero() bool { return ts.Has(TagTierZero) }
+
+// IsOwned reports whether the set contains the owned tag.
+func (ts TagSet) IsOwned() bool { return ts.Has(TagOwned) }
+
+// HasTag reports whether the entity carries the given system tag.
+func (e BaseEntity) HasTag(tag string) bool { return e.SystemTags.Has(tag) }
+
+// IsTierZero reports whether the entity is tagged Tier Zero.
+func (e BaseEntity) IsTierZero() bool { return e.SystemTags.IsTierZero() }
+
+// IsOwned reports whether the entity is marked as owned.
+func (e BaseEntity) IsOwned() bool { return e.SystemTags.IsOwned() }
+
+// HasTag reports whether the entity carries the given system tag.
+func (e BaseAzureEntity) HasTag(tag string) bool { return e.SystemTags.Has(tag) }
+
+// IsTierZero reports whether the entity is tagged Tier Zero.
+func (e BaseAzureEntity) IsTierZero() bool { return e.SystemTags.IsTierZero() }
+
+// IsOwned reports whether the entity is marked as owned.
+func (e BaseAzureEntity) IsOwned() bool { return e.SystemTags.IsOwned() }
+
+// HasTag reports whether the search result carries the given system tag.
+func (r SearchResult) HasTag(tag string) bool { return r.SystemTags.Has(tag) }
+
+// IsTierZero reports whether the search result is tagged Tier Zero.
+func (r SearchResult) IsTierZero() bool { return r.SystemTags.IsTierZero() }
+
+// IsOwned reports whether the search result is marked as owned.
+func (r SearchResult) IsOwned() bool { return r.SystemTags.IsOwned() }
+
+// Tags returns the system tags of the node, read from the node itself or, for Cypher
+// results, from its properties.
+func (n GraphNodeProperties) Tags() TagSet {
+	if len(n.SystemTags) > 0 {
+		return n.SystemTags
+	}
+	if value, ok := n.Properties["system_tags"].(string); ok {
+		return strings.Fields(value)
+	}
+	return nil
+}
+
+// HasTag reports whether the node carries the given system tag.
+func (n GraphNodeProperties) HasTag(tag string) bool { return n.Tags().Has(tag) }
+
+// IsTierZero reports whether the node is tagged Tier Zero, either by system tag or by kind.
+func (n GraphNodeProperties) IsTierZero() bool {
+	return n.Tags().IsTierZero() || n.HasKind(KindTagTierZero)
+}
+
+// IsOwned reports whether the node is marked as owned, either by system tag or by kind.
+func (n GraphNodeProperties) IsOwned() bool {
+	return n.Tags().IsOwned() || n.HasKind(KindTagOwned)
+}
+
+// HasKind reports whether the node has the given kind, either as its primary kind or as
+// one of its additional kinds.
+func (n GraphNodeProperties) HasKind(kind string) bool {
+	return strings.EqualFold(n.Kind, kind) || hasKind(n.Kinds, kind)
+}
+
+// HasKind reports whether the member has the given kind.
+func (m AssetGroupMember) HasKind(kind string) bool {
+	return strings.EqualFold(m.PrimaryKind, kind) || hasKind(m.Kinds, kind)
+}
+
+func hasKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeKind is a node label in the BloodHound graph.
+type NodeKind string
+
+// Active Directory node kinds.
+const (
+	KindBase           NodeKind = "Base"
+	KindUser           NodeKind = "User"
+	KindComputer       NodeKind = "Computer"
+	KindGroup          NodeKind = "Group"
+	KindGPO            NodeKind = "GPO"
+	KindOU             NodeKind = "OU"
+	KindContainer      NodeKind = "Container"
+	KindDomain         NodeKind = "Domain"
+	KindLocalGroup     NodeKind = "ADLocalGroup"
+	KindLocalUser      NodeKind = "ADLocalUser"
+	KindAIACA          NodeKind = "AIACA"
+	KindRootCA         NodeKind = "RootCA"
+	KindEnterpriseCA   NodeKind = "EnterpriseCA"
+	KindNTAuthStore    NodeKind = "NTAuthStore"
+	KindCertTemplate   NodeKind = "CertTemplate"
+	KindIssuancePolicy NodeKind = "IssuancePolicy"
+)
+
+// Azure node kinds.
+const (
+	KindAZBase              NodeKind = "AZBase"
+	KindAZUser              NodeKind = "AZUser"
+	KindAZGroup             NodeKind = "AZGroup"
+	KindAZTenant            NodeKind = "AZTenant"
+	KindAZVM                NodeKind = "AZVM"
+	KindAZServicePrincipal  NodeKind = "AZServicePrincipal"
+	KindAZApp               NodeKind = "AZApp"
+	KindAZDevice            NodeKind = "AZDevice"
+	KindAZRole              NodeKind = "AZRole"
+	KindAZManagementGroup   NodeKind = "AZManagementGroup"
+	KindAZSubscription      NodeKind = "AZSubscription"
+	KindAZResourceGroup     NodeKind = "AZResourceGroup"
+	KindAZKeyVault          NodeKind = "AZKeyVault"
+	KindAZAutomationAccount NodeKind = "AZAutomationAccount"
+	KindAZContainerRegistry NodeKind = "AZContainerRegistry"
+	KindAZFunctionApp       NodeKind = "AZFunctionApp"
+	KindAZLogicApp          NodeKind = "AZLogicApp"
+	KindAZWebApp            NodeKind = "AZWebApp"
+	KindAZManagedCluster    NodeKind = "AZManagedCluster"
+	KindAZVMScaleSet        NodeKind = "AZVMScaleSet"
+)
+
+// IsAzure reports whether the kind belongs to the Azure graph.
+func (k NodeKind) IsAzure() bool {
+	return strings.HasPrefix(string(k), "AZ")
+}
+
+// ADNodeKinds lists every Active Directory node kind except Base.
+var ADNodeKinds = []NodeKind{
+	KindUser, KindComputer, KindGroup, KindGPO, KindOU, KindContainer, KindDomain,
+	KindLocalGroup, KindLocalUser, KindAIACA, KindRootCA, KindEnterpriseCA,
+	KindNTAuthStore, KindCertTemplate, KindIssuancePolicy,
+}
+
+// AzureNodeKinds lists every Azure node kind except AZBase.
+var AzureNodeKinds = []NodeKind{
+	KindAZUser, KindAZGroup, KindAZTenant, KindAZVM, KindAZServicePrincipal, KindAZApp,
+	KindAZDevice, KindAZRole, KindAZManagementGroup, KindAZSubscription, KindAZResourceGroup,
+	KindAZKeyVault, KindAZAutomationAccount, KindAZContainerRegistry, KindAZFunctionApp,
+	KindAZLogicApp, KindAZWebApp, KindAZManagedCluster, KindAZVMScaleSet,
+}
+
+// EdgeKind is a relationship type in the BloodHound graph.
+type EdgeKind string
+
+// Active Directory edge kinds.
+const (
+	EdgeOwns                        EdgeKind = "Owns"
+	EdgeGenericAll                  EdgeKind = "GenericAll"
+	EdgeGenericWrite                EdgeKind = "GenericWrite"
+	EdgeWriteOwner                  EdgeKind = "WriteOwner"
+	EdgeWriteDacl                   EdgeKind = "WriteDacl"
+	EdgeMemberOf                    EdgeKind = "MemberOf"
+	EdgeForceChangePassword         EdgeKind = "ForceChangePassword"
+	EdgeAllExtendedRights           EdgeKind = "AllExtendedRights"
+	EdgeAddMember                   EdgeKind = "AddMember"
+	EdgeHasSession                  EdgeKind = "HasSession"
+	EdgeContains                    EdgeKind = "Contains"
+	EdgeGPLink                      EdgeKind = "GPLink"
+	EdgeAllowedToDelegate           EdgeKind = "AllowedToDelegate"
+	EdgeCoerceToTGT                 EdgeKind = "CoerceToTGT"
+	EdgeGetChanges                  EdgeKind = "GetChanges"
+	EdgeGetChangesAll               EdgeKind = "GetChangesAll"
+	EdgeGetChangesInFilteredSet     EdgeKind = "GetChangesInFilteredSet"
+	EdgeSameForestTrust             EdgeKind = "SameForestTrust"
+	EdgeCrossForestTrust            EdgeKind = "CrossForestTrust"
+	EdgeSpoofSIDHistory             EdgeKind = "SpoofSIDHistory"
+	EdgeAbuseTGTDelegation          EdgeKind = "AbuseTGTDelegation"
+	EdgeAllowedToAct                EdgeKind = "AllowedToAct"
+	EdgeAdminTo                     EdgeKind = "AdminTo"
+	EdgeCanPSRemote                 EdgeKind = "CanPSRemote"
+	EdgeCanRDP                      EdgeKind = "CanRDP"
+	EdgeExecuteDCOM                 EdgeKind = "ExecuteDCOM"
+	EdgeHasSIDHistory               EdgeKind = "HasSIDHistory"
+	EdgeAddSelf                     EdgeKind = "AddSelf"
+	EdgeDCSync                      EdgeKind = "DCSync"
+	EdgeReadLAPSPassword            EdgeKind = "ReadLAPSPassword"
+	EdgeReadGMSAPassword            EdgeKind = "ReadGMSAPassword"
+	EdgeDumpSMSAPassword            EdgeKind = "DumpSMSAPassword"
+	EdgeSQLAdmin                    EdgeKind = "SQLAdmin"
+	EdgeAddAllowedToAct             EdgeKind = "AddAllowedToAct"
+	EdgeWriteSPN                    EdgeKind = "WriteSPN"
+	EdgeAddKeyCredentialLink        EdgeKind = "AddKeyCredentialLink"
+	EdgeLocalToComputer             EdgeKind = "LocalToComputer"
+	EdgeMemberOfLocalGroup          EdgeKind = "MemberOfLocalGroup"
+	EdgeRemoteInteractiveLogonRight EdgeKind = "RemoteInteractiveLogonRight"
+	EdgeSyncLAPSPassword            EdgeKind = "SyncLAPSPassword"
+	EdgeWriteAccountRestrictions    EdgeKind = "WriteAccountRestrictions"
+	EdgeWriteGPLink                 EdgeKind = "WriteGPLink"
+	EdgeRootCAFor                   EdgeKind = "RootCAFor"
+	EdgeDCFor                       EdgeKind = "DCFor"
+	EdgePublishedTo                 EdgeKind = "PublishedTo"
+	EdgeManageCertificates          EdgeKind = "ManageCertificates"
+	EdgeManageCA                    EdgeKind = "ManageCA"
+	EdgeDelegatedEnrollmentAgent    EdgeKind = "DelegatedEnrollmentAgent"
+	EdgeEnroll                      EdgeKind = "Enroll"
+	EdgeHostsCAService              EdgeKind = "HostsCAService"
+	EdgeWritePKIEnrollmentFlag      EdgeKind = "WritePKIEnrollmentFlag"
+	EdgeWritePKINameFlag            EdgeKind = "WritePKINameFlag"
+	EdgeNTAuthStoreFor              EdgeKind = "NTAuthStoreFor"
+	EdgeTrustedForNTAuth            EdgeKind = "TrustedForNTAuth"
+	EdgeEnterpriseCAFor             EdgeKind = "EnterpriseCAFor"
+	EdgeIssuedSignedBy              EdgeKind = "IssuedSignedBy"
+	EdgeGoldenCert                  EdgeKind = "GoldenCert"
+	EdgeEnrollOnBehalfOf            EdgeKind = "EnrollOnBehalfOf"
+	EdgeOIDGroupLink                EdgeKind = "OIDGroupLink"
+	EdgeExtendedByPolicy            EdgeKind = "ExtendedByPolicy"
+	EdgeADCSESC1                    EdgeKind = "ADCSESC1"
+	EdgeADCSESC3                    EdgeKind = "ADCSESC3"
+	EdgeADCSESC4                    EdgeKind = "ADCSESC4"
+	EdgeADCSESC6a                   EdgeKind = "ADCSESC6a"
+	EdgeADCSESC6b                   EdgeKind = "ADCSESC6b"
+	EdgeADCSESC9a                   EdgeKind = "ADCSESC9a"
+	EdgeADCSESC9b                   EdgeKind = "ADCSESC9b"
+	EdgeADCSESC10a                  EdgeKind = "ADCSESC10a"
+	EdgeADCSESC10b                  EdgeKind = "ADCSESC10b"
+	EdgeADCSESC13                   EdgeKind = "ADCSESC13"
+	EdgeSyncedToEntraUser           EdgeKind = "SyncedToEntraUser"
+	EdgeCoerceAndRelayNTLMToSMB     EdgeKind = "CoerceAndRelayNTLMToSMB"
+	EdgeCoerceAndRelayNTLMToADCS    EdgeKind = "CoerceAndRelayNTLMToADCS"
+	EdgeCoerceAndRelayNTLMToLDAP    EdgeKind = "CoerceAndRelayNTLMToLDAP"
+	EdgeCoerceAndRelayNTLMToLDAPS   EdgeKind = "CoerceAndRelayNTLMToLDAPS"
+	EdgeWriteOwnerLimitedRights     EdgeKind = "WriteOwnerLimitedRights"
+	EdgeOwnsLimitedRights           EdgeKind = "OwnsLimitedRights"
+	EdgeWriteOwnerRaw               EdgeKind = "WriteOwnerRaw"
+	EdgeOwnsRaw                     EdgeKind = "OwnsRaw"
+	EdgeClaimSpecialIdentity        EdgeKind = "ClaimSpecialIdentity"
+	EdgeContainsIdentity            EdgeKind = "ContainsIdentity"
+	EdgePropagatesACEsTo            EdgeKind = "PropagatesACEsTo"
+	EdgeGPOAppliesTo                EdgeKind = "GPOAppliesTo"
+	EdgeCanApplyGPO                 EdgeKind = "CanApplyGPO"
+	EdgeHasTrustKeys                EdgeKind = "HasTrustKeys"
+	EdgeProtectAdminGroups          EdgeKind = "ProtectAdminGroups"
+	EdgeWriteAltSecurityIdentities  EdgeKind = "WriteAltSecurityIdentities"
+	EdgeWritePublicInformation      EdgeKind = "WritePublicInformation"
+	EdgeCanAbuseUPNCertMapping      EdgeKind = "CanAbuseUPNCertMapping"
+	EdgeCanAbuseWeakCertBinding     EdgeKind = "CanAbuseWeakCertBinding"
+)
+
+// EdgeTrustedBy is the domain trust edge of BloodHound versions before 6.0, replaced by
+// SameForestTrust and CrossForestTrust.
+//
+// Deprecated: Use EdgeSameForestTrust or EdgeCrossForestTrust.
+const EdgeTrustedBy EdgeKind = "TrustedBy"
+
+// Azure edge kinds.
+const (
+	EdgeAZAvereContributor                       EdgeKind = "AZAvereContributor"
+	EdgeAZContains                               EdgeKind = "AZContains"
+	EdgeAZContributor                            EdgeKind = "AZContributor"
+	EdgeAZGetCertificates                        EdgeKind = "AZGetCertificates"
+	EdgeAZGetKeys                                EdgeKind = "AZGetKeys"
+	EdgeAZGetSecrets                             EdgeKind = "AZGetSecrets"
+	EdgeAZHasRole                                EdgeKind = "AZHasRole"
+	EdgeAZMemberOf                               EdgeKind = "AZMemberOf"
+	EdgeAZOwner                                  EdgeKind = "AZOwner"
+	EdgeAZRunsAs                                 EdgeKind = "AZRunsAs"
+	EdgeAZVMContributor                          EdgeKind = "AZVMContributor"
+	EdgeAZAutomationContributor                  EdgeKind = "AZAutomationContributor"
+	EdgeAZKeyVaultKVContributor                  EdgeKind = "AZKeyVaultKVContributor"
+	EdgeAZVMAdminLogin                           EdgeKind = "AZVMAdminLogin"
+	EdgeAZAddMembers                             EdgeKind = "AZAddMembers"
+	EdgeAZAddSecret                              EdgeKind = "AZAddSecret"
+	EdgeAZExecuteCommand                         EdgeKind = "AZExecuteCommand"
+	EdgeAZGlobalAdmin                            EdgeKind = "AZGlobalAdmin"
+	EdgeAZPrivilegedAuthAdmin                    EdgeKind = "AZPrivilegedAuthAdmin"
+	EdgeAZGrant                                  EdgeKind = "AZGrant"
+	EdgeAZGrantSelf                              EdgeKind = "AZGrantSelf"
+	EdgeAZPrivilegedRoleAdmin                    EdgeKind = "AZPrivilegedRoleAdmin"
+	EdgeAZResetPassword                          EdgeKind = "AZResetPassword"
+	EdgeAZUserAccessAdministrator                EdgeKind = "AZUserAccessAdministrator"
+	EdgeAZOwns                                   EdgeKind = "AZOwns"
+	EdgeAZScopedTo                               EdgeKind = "AZScopedTo"
+	EdgeAZCloudAppAdmin                          EdgeKind = "AZCloudAppAdmin"
+	EdgeAZAppAdmin                               EdgeKind = "AZAppAdmin"
+	EdgeAZAddOwner                               EdgeKind = "AZAddOwner"
+	EdgeAZManagedIdentity                        EdgeKind = "AZManagedIdentity"
+	EdgeAZMGApplicationReadWriteAll              EdgeKind = "AZMGApplication_ReadWrite_All"
+	EdgeAZMGAppRoleAssignmentReadWriteAll        EdgeKind = "AZMGAppRoleAssignment_ReadWrite_All"
+	EdgeAZMGDirectoryReadWriteAll                EdgeKind = "AZMGDirectory_ReadWrite_All"
+	EdgeAZMGGroupReadWriteAll                    EdgeKind = "AZMGGroup_ReadWrite_All"
+	EdgeAZMGGroupMemberReadWriteAll              EdgeKind = "AZMGGroupMember_ReadWrite_All"
+	EdgeAZMGRoleManagementReadWriteDirectory     EdgeKind = "AZMGRoleManagement_ReadWrite_Directory"
+	EdgeAZMGServicePrincipalEndpointReadWriteAll EdgeKind = "AZMGServicePrincipalEndpoint_ReadWrite_All"
+	EdgeAZAKSContributor                         EdgeKind = "AZAKSContributor"
+	EdgeAZNodeResourceGroup                      EdgeKind = "AZNodeResourceGroup"
+	EdgeAZWebsiteContributor                     EdgeKind = "AZWebsiteContributor"
+	EdgeAZLogicAppContributor                    EdgeKind = "AZLogicAppContributor"
+	EdgeAZMGAddMember                            EdgeKind = "AZMGAddMember"
+	EdgeAZMGAddOwner                             EdgeKind = "AZMGAddOwner"
+	EdgeAZMGAddSecret                            EdgeKind = "AZMGAddSecret"
+	EdgeAZMGGrantAppRoles                        EdgeKind = "AZMGGrantAppRoles"
+	EdgeAZMGGrantRole                            EdgeKind = "AZMGGrantRole"
+	EdgeAZRoleEligible                           EdgeKind = "AZRoleEligible"
+	EdgeAZRoleApprover                           EdgeKind = "AZRoleApprover"
+	EdgeSyncedToADUser                           EdgeKind = "SyncedToADUser"
+)
+
+// ADEdgeKinds lists every Active Directory edge kind.
+var ADEdgeKinds = []EdgeKind{
+	EdgeOwns, EdgeGenericAll, EdgeGenericWrite, EdgeWriteOwner, EdgeWriteDacl, EdgeMemberOf,
+	EdgeForceChangePassword, EdgeAllExtendedRights, EdgeAddMember, EdgeHasSession, EdgeContains,
+	EdgeGPLink, EdgeAllowedToDelegate, EdgeCoerceToTGT, EdgeGetChanges, EdgeGetChangesAll,
+	EdgeGetChangesInFilteredSet, EdgeSameForestTrust, EdgeCrossForestTrust, EdgeSpoofSIDHistory,
+	EdgeAbuseTGTDelegation, EdgeAllowedToAct, EdgeAdminTo, EdgeCanPSRemote,
+	EdgeCanRDP, EdgeExecuteDCOM, EdgeHasSIDHistory, EdgeAddSelf, EdgeDCSync,
+	EdgeReadLAPSPassword, EdgeReadGMSAPassword, EdgeDumpSMSAPassword, EdgeSQLAdmin,
+	EdgeAddAllowedToAct, EdgeWriteSPN, EdgeAddKeyCredentialLink, EdgeLocalToComputer,
+	EdgeMemberOfLocalGroup, EdgeRemoteInteractiveLogonRight, EdgeSyncLAPSPassword,
+	EdgeWriteAccountRestrictions, EdgeWriteGPLink, EdgeRootCAFor, EdgeDCFor, EdgePublishedTo,
+	EdgeManageCertificates, EdgeManageCA, EdgeDelegatedEnrollmentAgent, EdgeEnroll,
+	EdgeHostsCAService, EdgeWritePKIEnrollmentFlag, EdgeWritePKINameFlag, EdgeNTAuthStoreFor,
+	EdgeTrustedForNTAuth, EdgeEnterpriseCAFor, EdgeIssuedSignedBy, EdgeGoldenCert,
+	EdgeEnrollOnBehalfOf, EdgeOIDGroupLink, EdgeExtendedByPolicy, EdgeADCSESC1, EdgeADCSESC3,
+	EdgeADCSESC4, EdgeADCSESC6a, EdgeADCSESC6b, EdgeADCSESC9a, EdgeADCSESC9b, EdgeADCSESC10a,
+	EdgeADCSESC10b, EdgeADCSESC13, EdgeSyncedToEntraUser, EdgeCoerceAndRelayNTLMToSMB,
+	EdgeCoerceAndRelayNTLMToADCS, EdgeCoerceAndRelayNTLMToLDAP, EdgeCoerceAndRelayNTLMToLDAPS,
+	EdgeWriteOwnerLimitedRights, EdgeOwnsLimitedRights, EdgeWriteOwnerRaw, EdgeOwnsRaw,
+	EdgeClaimSpecialIdentity, EdgeContainsIdentity, EdgePropagatesACEsTo, EdgeGPOAppliesTo,
+	EdgeCanApplyGPO, EdgeHasTrustKeys, EdgeProtectAdminGroups, EdgeWriteAltSecurityIdentities,
+	EdgeWritePublicInformation, EdgeCanAbuseUPNCertMapping, EdgeCanAbuseWeakCertBinding,
+}
+
+// AzureEdgeKinds lists every Azure edge kind.
+var AzureEdgeKinds = []EdgeKind{
+	EdgeAZAvereContributor, EdgeAZContains, EdgeAZContributor, EdgeAZGetCertificates,
+	EdgeAZGetKeys, EdgeAZGetSecrets, EdgeAZHasRole, EdgeAZMemberOf, EdgeAZOwner, EdgeAZRunsAs,
+	EdgeAZVMContributor, EdgeAZAutomationContributor, EdgeAZKeyVaultKVContributor,
+	EdgeAZVMAdminLogin, EdgeAZAddMembers, EdgeAZAddSecret, EdgeAZExecuteCommand,
+	EdgeAZGlobalAdmin, EdgeAZPrivilegedAuthAdmin, EdgeAZGrant, EdgeAZGrantSelf,
+	EdgeAZPrivilegedRoleAdmin, EdgeAZResetPassword, EdgeAZUserAccessAdministrator, EdgeAZOwns,
+	EdgeAZScopedTo, EdgeAZCloudAppAdmin, EdgeAZAppAdmin, EdgeAZAddOwner, EdgeAZManagedIdentity,
+	EdgeAZMGApplicationReadWriteAll, EdgeAZMGAppRoleAssignmentReadWriteAll,
+	EdgeAZMGDirectoryReadWriteAll, EdgeAZMGGroupReadWriteAll, EdgeAZMGGroupMemberReadWriteAll,
+	EdgeAZMGRoleManagementReadWriteDirectory, EdgeAZMGServicePrincipalEndpointReadWriteAll,
+	EdgeAZAKSContributor, EdgeAZNodeResourceGroup, EdgeAZWebsiteContributor,
+	EdgeAZLogicAppContributor, EdgeAZMGAddMember, EdgeAZMGAddOwner, EdgeAZMGAddSecret,
+	EdgeAZMGGrantAppRoles, EdgeAZMGGrantRole, EdgeAZRoleEligible, EdgeAZRoleApprover,
+	EdgeSyncedToADUser,
+}
+
+// EdgeKind returns the relationship type of the edge.
+func (e GraphEdge) EdgeKind() EdgeKind {
+	if e.Kind != "" {
+		return EdgeKind(e.Kind)
+	}
+	return EdgeKind(e.Label)
+}
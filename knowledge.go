@@ -0,0 +1,78 @@
+package bloodhound
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// EdgeKnowledge is the report text for an edge kind: what it means, how it is abused from
+// Windows and Linux, what the abuse leaves behind and how to remediate it. Text fields are
+// Markdown.
+type EdgeKnowledge struct {
+	Kind         EdgeKind `json:"-"`
+	General      string   `json:"general"`
+	WindowsAbuse string   `json:"windows_abuse"`
+	LinuxAbuse   string   `json:"linux_abuse"`
+	Opsec        string   `json:"opsec"`
+	References   []string `json:"references"`
+	Remediation  string   `json:"remediation"`
+}
+
+//go:embed knowledge/edges.json
+var edgeKnowledgeJSON []byte
+
+type knowledgeBase struct {
+	Version string                     `json:"version"`
+	Edges   map[EdgeKind]EdgeKnowledge `json:"edges"`
+}
+
+var loadKnowledgeBase = sync.OnceValue(func() knowledgeBase {
+	var kb knowledgeBase
+	if err := json.Unmarshal(edgeKnowledgeJSON, &kb); err != nil {
+		panic(fmt.Sprintf("bloodhound: invalid embedded edge knowledge base: %v", err))
+	}
+	for kind, knowledge := range kb.Edges {
+		knowledge.Kind = kind
+		kb.Edges[kind] = knowledge
+	}
+	return kb
+})
+
+// KnowledgeBaseVersion returns the version of the embedded edge knowledge base, so
+// reports can record which text they were generated from.
+func KnowledgeBaseVersion() string {
+	return loadKnowledgeBase().Version
+}
+
+// LookupEdgeKnowledge returns the knowledge base entry for an edge kind.
+func LookupEdgeKnowledge(kind EdgeKind) (EdgeKnowledge, bool) {
+	knowledge, ok := loadKnowledgeBase().Edges[kind]
+	if ok {
+		knowledge.References = append([]string(nil), knowledge.References...)
+	}
+	return knowledge, ok
+}
+
+// KnowledgeEdgeKinds returns the edge kinds covered by the knowledge base, sorted.
+func KnowledgeEdgeKinds() []EdgeKind {
+	kb := loadKnowledgeBase()
+	kinds := make([]EdgeKind, 0, len(kb.Edges))
+	for kind := range kb.Edges {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+// Knowledge returns the knowledge base entry for the edge's kind.
+func (e GraphEdge) Knowledge() (EdgeKnowledge, bool) {
+	return LookupEdgeKnowledge(e.EdgeKind())
+}
+
+// Knowledge returns the knowledge base entry for the hop's edge kind.
+func (h Hop) Knowledge() (EdgeKnowledge, bool) {
+	return LookupEdgeKnowledge(h.Kind)
+}
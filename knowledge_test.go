@@ -0,0 +1,60 @@
+package bloodhound
+
+import "testing"
+
+func TestEdgeKnowledgeBase(t *testing.T) {
+	if KnowledgeBaseVersion() == "" {
+		t.Error("knowledge base has no version")
+	}
+
+	known := map[EdgeKind]bool{}
+	for _, kind := range append(append([]EdgeKind{}, ADEdgeKinds...), AzureEdgeKinds...) {
+		known[kind] = true
+	}
+	for _, kind := range KnowledgeEdgeKinds() {
+		knowledge, _ := LookupEdgeKnowledge(kind)
+		if !known[kind] {
+			t.Errorf("knowledge base entry for unknown edge kind %s", kind)
+		}
+		if knowledge.Kind != kind || knowledge.General == "" || knowledge.Remediation == "" {
+			t.Errorf("incomplete knowledge base entry for %s: %+v", kind, knowledge)
+		}
+		if len(knowledge.References) == 0 {
+			t.Errorf("knowledge base entry for %s has no references", kind)
+		}
+	}
+
+	knowledge, ok := GraphEdge{Label: "ForceChangePassword"}.Knowledge()
+	if !ok || knowledge.Kind != EdgeForceChangePassword || knowledge.WindowsAbuse == "" || knowledge.LinuxAbuse == "" {
+		t.Errorf("unexpected knowledge for ForceChangePassword: %+v", knowledge)
+	}
+	if _, ok := LookupEdgeKnowledge("NotAnEdge"); ok {
+		t.Error("found knowledge for an unknown edge kind")
+	}
+}
+
+func TestEdgeKnowledgeBaseCoverage(t *testing.T) {
+	kinds := append(append([]EdgeKind{}, CompositeEdgeKinds...), TraversableEdgeKinds()...)
+	for _, kind := range kinds {
+		if _, ok := LookupEdgeKnowledge(kind); !ok {
+			t.Errorf("no knowledge base entry for %s", kind)
+		}
+	}
+}
+
+// TestEdgeKnowledgeBaseDistinct checks that no opsec or Linux abuse text is shared between
+// entries, so each one describes its own edge.
+func TestEdgeKnowledgeBaseDistinct(t *testing.T) {
+	opsec, linuxAbuse := map[string]EdgeKind{}, map[string]EdgeKind{}
+	for _, kind := range KnowledgeEdgeKinds() {
+		knowledge, _ := LookupEdgeKnowledge(kind)
+		if other, ok := opsec[knowledge.Opsec]; ok {
+			t.Errorf("%s and %s share their opsec text", other, kind)
+		}
+		opsec[knowledge.Opsec] = kind
+		if other, ok := linuxAbuse[knowledge.LinuxAbuse]; ok {
+			t.Errorf("%s and %s share their Linux abuse text", other, kind)
+		}
+		linuxAbuse[knowledge.LinuxAbuse] = kind
+	}
+}
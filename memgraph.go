@@ -0,0 +1,393 @@
+package bloodhound
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Graph is an in-memory directed multigraph built from graph-shaped API results, for
+// analysing a subgraph locally. Nodes are keyed by their graph ID. A Graph is not safe
+// for concurrent modification.
+type Graph struct {
+	nodes    map[string]GraphNodeProperties
+	edges    []GraphEdge
+	out      map[string][]int // node ID -> indexes into edges
+	in       map[string][]int
+	byObject map[string]string // upper-case Object ID -> node ID
+}
+
+// NewGraph returns an empty graph.
+func NewGraph() *Graph {
+	return &Graph{
+		nodes:    map[string]GraphNodeProperties{},
+		out:      map[string][]int{},
+		in:       map[string][]int{},
+		byObject: map[string]string{},
+	}
+}
+
+// GraphFromCypher builds a graph from a Cypher graph result.
+func GraphFromCypher(data *CypherResponseData) *Graph {
+	g := NewGraph()
+	g.AddGraph(data.Nodes, data.Edges)
+	return g
+}
+
+// GraphFromShortestPath builds a graph from a shortest path or edge composition result.
+func GraphFromShortestPath(data ShortestPathData) *Graph {
+	g := NewGraph()
+	g.AddGraph(data.Nodes, data.Edges)
+	return g
+}
+
+// AddGraph merges the nodes and edges of a graph-shaped result into the graph.
+// Edges already present with the same source, target and kind are not duplicated.
+func (g *Graph) AddGraph(nodes map[string]GraphNodeProperties, edges []GraphEdge) {
+	for id, node := range nodes {
+		g.AddNode(id, node)
+	}
+	for _, edge := range edges {
+		if !g.hasEdge(edge) {
+			g.AddEdge(edge)
+		}
+	}
+}
+
+// AddNode adds a node, or replaces the properties of an existing one.
+func (g *Graph) AddNode(id string, node GraphNodeProperties) {
+	g.nodes[id] = node
+	if objectID := objectIDOf(node); objectID != "" {
+		g.byObject[strings.ToUpper(objectID)] = id
+	}
+}
+
+// AddEdge adds an edge. Endpoints that are not in the graph yet are added without properties.
+func (g *Graph) AddEdge(edge GraphEdge) {
+	for _, id := range []string{edge.Source, edge.Target} {
+		if _, ok := g.nodes[id]; !ok {
+			g.nodes[id] = GraphNodeProperties{}
+		}
+	}
+	g.edges = append(g.edges, edge)
+	g.out[edge.Source] = append(g.out[edge.Source], len(g.edges)-1)
+	g.in[edge.Target] = append(g.in[edge.Target], len(g.edges)-1)
+}
+
+func (g *Graph) hasEdge(edge GraphEdge) bool {
+	for _, i := range g.out[edge.Source] {
+		if g.edges[i].Target == edge.Target && g.edges[i].EdgeKind() == edge.EdgeKind() {
+			return true
+		}
+	}
+	return false
+}
+
+// Node returns the properties of a node.
+func (g *Graph) Node(id string) (GraphNodeProperties, bool) {
+	node, ok := g.nodes[id]
+	return node, ok
+}
+
+// NodeIDForObject returns the graph ID of the node with the given Object ID.
+func (g *Graph) NodeIDForObject(objectID string) (string, bool) {
+	id, ok := g.byObject[strings.ToUpper(objectID)]
+	return id, ok
+}
+
+// NodeIDs returns the IDs of every node, sorted.
+func (g *Graph) NodeIDs() []string {
+	ids := make([]string, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Edges returns every edge of the graph.
+func (g *Graph) Edges() []GraphEdge {
+	return append([]GraphEdge{}, g.edges...)
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph) NodeCount() int { return len(g.nodes) }
+
+// EdgeCount returns the number of edges.
+func (g *Graph) EdgeCount() int { return len(g.edges) }
+
+// OutEdges returns the edges leaving a node that the filter allows.
+func (g *Graph) OutEdges(id string, filter PathFilter) []GraphEdge {
+	return g.filterEdges(g.out[id], filter)
+}
+
+// InEdges returns the edges entering a node that the filter allows.
+func (g *Graph) InEdges(id string, filter PathFilter) []GraphEdge {
+	return g.filterEdges(g.in[id], filter)
+}
+
+func (g *Graph) filterEdges(indexes []int, filter PathFilter) []GraphEdge {
+	var edges []GraphEdge
+	for _, i := range indexes {
+		if filter.Allows(g.edges[i].EdgeKind()) {
+			edges = append(edges, g.edges[i])
+		}
+	}
+	return edges
+}
+
+// VisitFunc is called for every node reached by a traversal, with its distance in hops
+// from the start. Returning false stops the traversal.
+type VisitFunc func(id string, depth int) bool
+
+// BFS visits the nodes reachable from start in breadth-first order, following only the
+// edges the filter allows.
+func (g *Graph) BFS(start string, filter PathFilter, visit VisitFunc) {
+	if _, ok := g.nodes[start]; !ok {
+		return
+	}
+	type item struct {
+		id    string
+		depth int
+	}
+	seen := map[string]bool{start: true}
+	queue := []item{{start, 0}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if !visit(current.id, current.depth) {
+			return
+		}
+		for _, edge := range g.OutEdges(current.id, filter) {
+			if !seen[edge.Target] {
+				seen[edge.Target] = true
+				queue = append(queue, item{edge.Target, current.depth + 1})
+			}
+		}
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first order, following only the
+// edges the filter allows.
+func (g *Graph) DFS(start string, filter PathFilter, visit VisitFunc) {
+	if _, ok := g.nodes[start]; !ok {
+		return
+	}
+	seen := map[string]bool{}
+	var walk func(id string, depth int) bool
+	walk = func(id string, depth int) bool {
+		seen[id] = true
+		if !visit(id, depth) {
+			return false
+		}
+		for _, edge := range g.OutEdges(id, filter) {
+			if !seen[edge.Target] && !walk(edge.Target, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(start, 0)
+}
+
+// Descendants returns the IDs of the nodes reachable from id, sorted, excluding id itself.
+func (g *Graph) Descendants(id string, filter PathFilter) []string {
+	return g.closure(id, filter, g.OutEdges, func(e GraphEdge) string { return e.Target })
+}
+
+// Ancestors returns the IDs of the nodes from which id is reachable, sorted, excluding id itself.
+func (g *Graph) Ancestors(id string, filter PathFilter) []string {
+	return g.closure(id, filter, g.InEdges, func(e GraphEdge) string { return e.Source })
+}
+
+func (g *Graph) closure(id string, filter PathFilter, next func(string, PathFilter) []GraphEdge, other func(GraphEdge) string) []string {
+	seen := map[string]bool{id: true}
+	stack := []string{id}
+	var result []string
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, edge := range next(current, filter) {
+			neighbour := other(edge)
+			if !seen[neighbour] {
+				seen[neighbour] = true
+				result = append(result, neighbour)
+				stack = append(stack, neighbour)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Reachable returns the set of nodes reachable from any of the start nodes, including
+// the start nodes themselves.
+func (g *Graph) Reachable(filter PathFilter, starts ...string) map[string]bool {
+	reachable := map[string]bool{}
+	for _, start := range starts {
+		if _, ok := g.nodes[start]; !ok || reachable[start] {
+			continue
+		}
+		reachable[start] = true
+		for _, id := range g.Descendants(start, filter) {
+			reachable[id] = true
+		}
+	}
+	return reachable
+}
+
+// EdgeCost returns the cost of traversing an edge; it must not be negative. Returning
+// math.Inf(1) makes the edge impassable.
+type EdgeCost func(GraphEdge) float64
+
+// UnitCost gives every edge a cost of 1, so the cheapest path is the one with fewest hops.
+func UnitCost(GraphEdge) float64 { return 1 }
+
+// CheapestPath finds the lowest-cost path from start to end with Dijkstra's algorithm.
+// A nil cost function uses UnitCost. It reports false when end is unreachable.
+func (g *Graph) CheapestPath(start, end string, cost EdgeCost) (Path, float64, bool) {
+	if cost == nil {
+		cost = UnitCost
+	}
+	if _, ok := g.nodes[start]; !ok {
+		return Path{}, 0, false
+	}
+
+	dist := map[string]float64{start: 0}
+	via := map[string]int{} // node ID -> index of the edge it was reached by
+	done := map[string]bool{}
+	queue := &costQueue{{id: start}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(costItem)
+		if done[current.id] {
+			continue
+		}
+		done[current.id] = true
+		if current.id == end {
+			break
+		}
+		for _, i := range g.out[current.id] {
+			edge := g.edges[i]
+			c := cost(edge)
+			if math.IsInf(c, 1) || c < 0 {
+				continue
+			}
+			next := current.cost + c
+			if known, ok := dist[edge.Target]; !ok || next < known {
+				dist[edge.Target] = next
+				via[edge.Target] = i
+				heap.Push(queue, costItem{id: edge.Target, cost: next})
+			}
+		}
+	}
+	if !done[end] {
+		return Path{}, 0, false
+	}
+
+	var edges []GraphEdge
+	for id := end; id != start; {
+		edge := g.edges[via[id]]
+		edges = append([]GraphEdge{edge}, edges...)
+		id = edge.Source
+	}
+	return newPath(g.nodes, start, edges), dist[end], true
+}
+
+type costItem struct {
+	id   string
+	cost float64
+}
+
+type costQueue []costItem
+
+func (q costQueue) Len() int            { return len(q) }
+func (q costQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q costQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *costQueue) Push(x interface{}) { *q = append(*q, x.(costItem)) }
+func (q *costQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph
+// (Tarjan's algorithm), each sorted, largest first. Cycles such as nested group
+// memberships show up as components with more than one node.
+func (g *Graph) StronglyConnectedComponents() [][]string {
+	index := 0
+	indexes, lowlinks := map[string]int{}, map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var components [][]string
+
+	var connect func(id string)
+	connect = func(id string) {
+		indexes[id], lowlinks[id] = index, index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, i := range g.out[id] {
+			target := g.edges[i].Target
+			if _, visited := indexes[target]; !visited {
+				connect(target)
+				lowlinks[id] = min(lowlinks[id], lowlinks[target])
+			} else if onStack[target] {
+				lowlinks[id] = min(lowlinks[id], indexes[target])
+			}
+		}
+
+		if lowlinks[id] == indexes[id] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == id {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, id := range g.NodeIDs() {
+		if _, visited := indexes[id]; !visited {
+			connect(id)
+		}
+	}
+	sort.SliceStable(components, func(i, j int) bool { return len(components[i]) > len(components[j]) })
+	return components
+}
+
+// Subgraph returns the subgraph induced by the given node IDs: those nodes and every
+// edge between them.
+func (g *Graph) Subgraph(ids []string) *Graph {
+	keep := map[string]bool{}
+	sub := NewGraph()
+	for _, id := range ids {
+		if node, ok := g.nodes[id]; ok {
+			keep[id] = true
+			sub.AddNode(id, node)
+		}
+	}
+	for _, edge := range g.edges {
+		if keep[edge.Source] && keep[edge.Target] {
+			sub.AddEdge(edge)
+		}
+	}
+	return sub
+}
+
+// ShortestPathData converts the graph back to the shape of a shortest path result.
+func (g *Graph) ShortestPathData() ShortestPathData {
+	nodes := make(map[string]GraphNodeProperties, len(g.nodes))
+	for id, node := range g.nodes {
+		nodes[id] = node
+	}
+	return ShortestPathData{Nodes: nodes, Edges: g.Edges()}
+}
@@ -0,0 +1,93 @@
+package bloodhound
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testGraph() *Graph {
+	return GraphFromCypher(&CypherResponseData{
+		Nodes: map[string]GraphNodeProperties{
+			"u": {Name: "ALICE@CORP.LOCAL", Kind: "User", Kinds: []string{"Base", "User"}, ObjectID: "S-1-5-21-1-1104"},
+			"a": {Name: "A@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-1200"},
+			"b": {Name: "B@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-1201"},
+			"c": {Name: "WS01.CORP.LOCAL", Kind: "Computer", ObjectID: "S-1-5-21-1-1000"},
+			"d": {Name: "DOMAIN ADMINS@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-512"},
+		},
+		Edges: []GraphEdge{
+			{Source: "u", Target: "a", Kind: "MemberOf"},
+			{Source: "a", Target: "b", Kind: "MemberOf"},
+			{Source: "b", Target: "a", Kind: "MemberOf"},
+			{Source: "b", Target: "d", Kind: "GenericAll"},
+			{Source: "u", Target: "c", Kind: "AdminTo"},
+			{Source: "c", Target: "d", Kind: "HasSession"},
+		},
+	})
+}
+
+// withNodeProperties replaces the properties of a node of g and returns g.
+func withNodeProperties(g *Graph, id string, properties map[string]interface{}) *Graph {
+	node, _ := g.Node(id)
+	node.Properties = properties
+	g.AddNode(id, node)
+	return g
+}
+
+func TestGraphTraversal(t *testing.T) {
+	g := testGraph()
+
+	var order []string
+	g.BFS("u", PathFilter{}, func(id string, depth int) bool {
+		order = append(order, id)
+		return true
+	})
+	if len(order) != 5 || order[0] != "u" || order[len(order)-1] != "d" {
+		t.Errorf("unexpected BFS order: %v", order)
+	}
+
+	if got := g.Descendants("u", OnlyEdges(EdgeMemberOf)); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Descendants() = %v", got)
+	}
+	if got := g.Ancestors("d", ExcludeEdges(EdgeHasSession)); !reflect.DeepEqual(got, []string{"a", "b", "u"}) {
+		t.Errorf("Ancestors() = %v", got)
+	}
+
+	components := g.StronglyConnectedComponents()
+	if !reflect.DeepEqual(components[0], []string{"a", "b"}) || len(components) != 4 {
+		t.Errorf("StronglyConnectedComponents() = %v", components)
+	}
+
+	if id, ok := g.NodeIDForObject("s-1-5-21-1-512"); !ok || id != "d" {
+		t.Errorf("NodeIDForObject() = %q, %v", id, ok)
+	}
+
+	sub := g.Subgraph([]string{"a", "b", "d"})
+	if sub.NodeCount() != 3 || sub.EdgeCount() != 3 {
+		t.Errorf("Subgraph() has %d nodes and %d edges", sub.NodeCount(), sub.EdgeCount())
+	}
+}
+
+func TestGraphCheapestPath(t *testing.T) {
+	g := testGraph()
+
+	path, cost, ok := g.CheapestPath("u", "d", nil)
+	if !ok || cost != 2 || path.String() != "ALICE@CORP.LOCAL -AdminTo-> WS01.CORP.LOCAL -HasSession-> DOMAIN ADMINS@CORP.LOCAL" {
+		t.Errorf("CheapestPath() = %s (cost %v, ok %v)", path, cost, ok)
+	}
+
+	// Make session-based hops expensive so the group nesting path wins.
+	sessionCost := func(e GraphEdge) float64 {
+		if e.EdgeKind() == EdgeHasSession {
+			return 10
+		}
+		return 1
+	}
+	path, cost, ok = g.CheapestPath("u", "d", sessionCost)
+	if !ok || cost != 3 || path.Len() != 3 {
+		t.Errorf("CheapestPath() with costs = %s (cost %v, ok %v)", path, cost, ok)
+	}
+
+	if _, _, ok := g.CheapestPath("d", "u", nil); ok {
+		t.Error("expected no path from d to u")
+	}
+}
@@ -2,16 +2,19 @@ package bloodhound
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"strconv"
 	"time"
 )
 
 // JsonTime is a custom time type to handle Unix timestamps from the API.
+// It also accepts fractional timestamps and RFC 3339 strings, and marshals back to RFC 3339.
 type JsonTime time.Time
 
 func (jt *JsonTime) UnmarshalJSON(b []byte) error {
 	s := string(b)
-	if s == "null" || s == "" || s == "0" {
+	if s == "null" || s == "" || s == "0" || s == "-1" {
 		*jt = JsonTime(time.Time{})
 		return nil
 	}
@@ -20,6 +23,16 @@ func (jt *JsonTime) UnmarshalJSON(b []byte) error {
 		*jt = JsonTime(time.Unix(i, 0))
 		return nil
 	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err == nil {
+		if f <= 0 {
+			*jt = JsonTime(time.Time{})
+			return nil
+		}
+		sec, frac := math.Modf(f)
+		*jt = JsonTime(time.Unix(int64(sec), int64(frac*1e9)))
+		return nil
+	}
 	t, err := time.Parse(`"`+time.RFC3339+`"`, s)
 	if err != nil {
 		*jt = JsonTime(time.Time{})
@@ -29,6 +42,25 @@ func (jt *JsonTime) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes the time as an RFC 3339 string, or null when it is not set.
+func (jt JsonTime) MarshalJSON() ([]byte, error) {
+	t := time.Time(jt)
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.UTC().Format(time.RFC3339Nano) + `"`), nil
+}
+
+// Time returns the value as a time.Time.
+func (jt JsonTime) Time() time.Time {
+	return time.Time(jt)
+}
+
+// IsZero reports whether the time is not set.
+func (jt JsonTime) IsZero() bool {
+	return time.Time(jt).IsZero()
+}
+
 func (jt JsonTime) String() string {
 	t := time.Time(jt)
 	if t.IsZero() {
@@ -114,63 +146,79 @@ type BaseEntity struct {
 	ObjectID          string   `json:"objectid"`
 	Name              string   `json:"name"`
 	DistinguishedName string   `json:"distinguishedname"`
-	SystemTags        string   `json:"system_tags"`
+	Domain            string   `json:"domain"`
+	DomainSID         string   `json:"domainsid"`
+	Description       string   `json:"description"`
+	IsACLProtected    bool     `json:"isaclprotected"`
+	SystemTags        TagSet   `json:"system_tags"`
 	WhenCreated       JsonTime `json:"whencreated"`
+	LastSeen          JsonTime `json:"lastseen"`
 	ObjectType        string   `json:"type"`
+	// Extra holds the properties that have no dedicated field.
+	Extra map[string]any `json:"-"`
 }
 
 // Computer represents a computer in BloodHound.
 type Computer struct {
-	Name                     string      `json:"name"`
-	ObjectID                 string      `json:"objectid"`
-	ObjectType               string      `json:"object_type"`
-	DistinguishedName        string      `json:"distinguishedname"`
-	OperatingSystem          string      `json:"operatingsystem"`
-	Enabled                  bool        `json:"enabled"`
-	HasLAPS                  bool        `json:"haslaps"`
-	WhenCreated              interface{} `json:"whencreated"`
-	LastSeen                 string      `json:"lastseen"`
-	ServicePrincipalNames    []string    `json:"serviceprincipalnames"`
-	UnconstrainedDelegation  bool        `json:"unconstraineddelegation"`
-	SupportedEncryptionTypes []string    `json:"supportedencryptiontypes"`
-	LastLogon                interface{} `json:"lastlogon"`
-	IsDC                     bool        `json:"isdc"`
-	AdminRights              int         `json:"adminRights"`
-	AdminUsers               int         `json:"adminUsers"`
-	ConstrainedPrivs         int         `json:"constrainedPrivs"`
-	ConstrainedUsers         int         `json:"constrainedUsers"`
-	Controllables            int         `json:"controllables"`
-	Controllers              int         `json:"controllers"`
-	DCOMRights               int         `json:"dcomRights"`
-	DCOMUsers                int         `json:"dcomUsers"`
-	GPOs                     int         `json:"gpos"`
-	GroupMembership          int         `json:"groupMembership"`
-	PSRemoteRights           int         `json:"psRemoteRights"`
-	PSRemoteUsers            int         `json:"psRemoteUsers"`
-	RDPRights                int         `json:"rdpRights"`
-	Sessions                 int         `json:"sessions"`
-	SQLAdminUsers            int         `json:"sqlAdminUsers"`
+	BaseEntity
+	SamAccountName           string   `json:"samaccountname"`
+	OperatingSystem          string   `json:"operatingsystem"`
+	Enabled                  bool     `json:"enabled"`
+	HasLAPS                  bool     `json:"haslaps"`
+	IsDC                     bool     `json:"isdc"`
+	IsAdmin                  bool     `json:"admincount"`
+	UnconstrainedDelegation  bool     `json:"unconstraineddelegation"`
+	TrustedToAuth            bool     `json:"trustedtoauth"`
+	AllowedToDelegate        []string `json:"allowedtodelegate"`
+	ServicePrincipalNames    []string `json:"serviceprincipalnames"`
+	SupportedEncryptionTypes []string `json:"supportedencryptiontypes"`
+	SIDHistory               []string `json:"sidhistory"`
+	LastLogon                JsonTime `json:"lastlogon"`
+	LastLogonTimestamp       JsonTime `json:"lastlogontimestamp"`
+	PwdLastSet               JsonTime `json:"pwdlastset"`
+	// Relationship Counts
+	AdminRights      int `json:"adminRights"`
+	AdminUsers       int `json:"adminUsers"`
+	ConstrainedPrivs int `json:"constrainedPrivs"`
+	ConstrainedUsers int `json:"constrainedUsers"`
+	Controllables    int `json:"controllables"`
+	Controllers      int `json:"controllers"`
+	DCOMRights       int `json:"dcomRights"`
+	DCOMUsers        int `json:"dcomUsers"`
+	GPOs             int `json:"gpos"`
+	GroupMembership  int `json:"groupMembership"`
+	PSRemoteRights   int `json:"psRemoteRights"`
+	PSRemoteUsers    int `json:"psRemoteUsers"`
+	RDPRights        int `json:"rdpRights"`
+	Sessions         int `json:"sessions"`
+	SQLAdminUsers    int `json:"sqlAdminUsers"`
 }
 
 // ADUser represents a BloodHound AD User object.
 type ADUser struct {
 	BaseEntity
+	SamAccountName          string   `json:"samaccountname"`
 	DisplayName             string   `json:"displayname"`
-	Description             string   `json:"description"`
+	Title                   string   `json:"title"`
 	Email                   string   `json:"email"`
-	Domain                  string   `json:"domain"`
-	DomainSID               string   `json:"domainsid"`
+	HomeDirectory           string   `json:"homedirectory"`
+	LogonScript             string   `json:"logonscript"`
 	Enabled                 bool     `json:"enabled"`
 	HasSIDHistory           bool     `json:"hassidhistory"`
+	SIDHistory              []string `json:"sidhistory"`
 	IsAdmin                 bool     `json:"admincount"`
+	Sensitive               bool     `json:"sensitive"`
 	DontReqPreAuth          bool     `json:"dontreqpreauth"`
+	PasswordNotReqd         bool     `json:"passwordnotreqd"`
 	LockedOut               bool     `json:"lockedout"`
 	PwdNeverExpires         bool     `json:"pwdneverexpires"`
 	UnconstrainedDelegation bool     `json:"unconstraineddelegation"`
+	TrustedToAuth           bool     `json:"trustedtoauth"`
+	AllowedToDelegate       []string `json:"allowedtodelegate"`
 	HasSPN                  bool     `json:"hasspn"`
 	ServicePrincipalNames   []string `json:"serviceprincipalnames"`
+	LastLogon               JsonTime `json:"lastlogon"`
 	LastLogonTimestamp      JsonTime `json:"lastlogontimestamp"`
-	LastSeen                JsonTime `json:"lastseen"`
 	PwdLastSet              JsonTime `json:"pwdlastset"`
 	// Relationship Counts
 	AdminRights           int `json:"adminRights"`
@@ -190,7 +238,6 @@ type ADUser struct {
 type Group struct {
 	BaseEntity
 	IsAdmin        bool   `json:"admincount"`
-	Description    string `json:"description"`
 	SamAccountName string `json:"samaccountname"`
 	// Relationship Counts
 	AdminRights    int `json:"adminRights"`
@@ -205,93 +252,107 @@ type Group struct {
 
 // Domain represents a domain in BloodHound.
 type Domain struct {
-	Name                     string      `json:"name"`
-	ObjectID                 string      `json:"objectid"`
-	ObjectType               string      `json:"object_type"`
-	DistinguishedName        string      `json:"distinguishedname"`
-	WhenCreated              interface{} `json:"whencreated"`
-	Users                    int         `json:"users"`
-	Computers                int         `json:"computers"`
-	Controllers              int         `json:"controllers"`
-	DCSyncers                int         `json:"dcsyncers"`
-	ForeignAdmins            int         `json:"foreignAdmins"`
-	ForeignGPOControllers    int         `json:"foreignGPOControllers"`
-	ForeignGroups            int         `json:"foreignGroups"`
-	ForeignUsers             int         `json:"foreignUsers"`
-	GPOs                     int         `json:"gpos"`
-	Groups                   int         `json:"groups"`
-	InboundTrusts            int         `json:"inboundTrusts"`
-	LinkedGPOs               int         `json:"linkedgpos"`
-	OUs                      int         `json:"ous"`
-	OutboundTrusts           int         `json:"outboundTrusts"`
-	FSMORoleOwner            string      `json:"fsmoroleowner"`
-	DC                       string      `json:"dc"`
-	FunctionalLevel          string      `json:"functionallevel"`
-	MachineAccountQuota      int         `json:"ms-ds-machineaccountquota"`
-	AllUsersTrustQuota       int         `json:"msds-alluserstrustquota"`
-	IsACLProtected           bool        `json:"isaclprotected"`
-	IsCriticalSystemObject   bool        `json:"iscriticalsystemobject"`
-	LastCollected            string      `json:"lastcollected"`
-	LastSeen                 string      `json:"lastseen"`
-	LockoutDuration          interface{} `json:"lockoutduration"`
-	LockoutObservationWindow interface{} `json:"lockoutobservationwindow"`
-	LockoutThreshold         int         `json:"lockoutthreshold"`
-	MasteredBy               interface{} `json:"masteredby"`
-	MSDSMasteredBy           interface{} `json:"msds-masteredby"`
-	WellKnownObjects         []string    `json:"wellknownobjects"`
+	BaseEntity
+	FunctionalLevel                        string   `json:"functionallevel"`
+	IsCriticalSystemObject                 bool     `json:"iscriticalsystemobject"`
+	ExpirePasswordsOnSmartCardOnlyAccounts bool     `json:"expirepasswordsonsmartcardonlyaccounts"`
+	MachineAccountQuota                    int      `json:"ms-ds-machineaccountquota"`
+	AllUsersTrustQuota                     int      `json:"msds-alluserstrustquota"`
+	MinPwdLength                           int      `json:"minpwdlength"`
+	PwdHistoryLength                       int      `json:"pwdhistorylength"`
+	PwdProperties                          int      `json:"pwdproperties"`
+	MinPwdAge                              string   `json:"minpwdage"`
+	MaxPwdAge                              string   `json:"maxpwdage"`
+	LockoutThreshold                       int      `json:"lockoutthreshold"`
+	LockoutDuration                        string   `json:"lockoutduration"`
+	LockoutObservationWindow               string   `json:"lockoutobservationwindow"`
+	FSMORoleOwner                          string   `json:"fsmoroleowner"`
+	DC                                     string   `json:"dc"`
+	LastCollected                          JsonTime `json:"lastcollected"`
+	WellKnownObjects                       []string `json:"wellknownobjects"`
+	MasteredBy                             []string `json:"masteredby"`
+	MSDSMasteredBy                         []string `json:"msds-masteredby"`
+	// Relationship Counts
+	Users                 int `json:"users"`
+	Computers             int `json:"computers"`
+	Controllers           int `json:"controllers"`
+	DCSyncers             int `json:"dcsyncers"`
+	ForeignAdmins         int `json:"foreignAdmins"`
+	ForeignGPOControllers int `json:"foreignGPOControllers"`
+	ForeignGroups         int `json:"foreignGroups"`
+	ForeignUsers          int `json:"foreignUsers"`
+	GPOs                  int `json:"gpos"`
+	Groups                int `json:"groups"`
+	InboundTrusts         int `json:"inboundTrusts"`
+	LinkedGPOs            int `json:"linkedgpos"`
+	OUs                   int `json:"ous"`
+	OutboundTrusts        int `json:"outboundTrusts"`
 }
 
 // GPO represents a BloodHound GPO object.
 type GPO struct {
-	Name              string      `json:"name"`
-	ObjectID          string      `json:"objectid"`
-	ObjectType        string      `json:"object_type"`
-	DistinguishedName string      `json:"distinguishedname"`
-	WhenCreated       interface{} `json:"whencreated"`
-	Computers         int         `json:"computers"`
-	OUs               int         `json:"ous"`
-	Users             int         `json:"users"`
-	IsACLProtected    bool        `json:"isaclprotected"`
-	GPCPath           string      `json:"gpcpath"`
-	IsTierZero        bool        `json:"tierzero"`
+	BaseEntity
+	GPCPath string `json:"gpcpath"`
+	// IsTierZero is the legacy tierzero property. It shadows BaseEntity.IsTierZero; use
+	// HasTag(TagTierZero) for the system tag.
+	IsTierZero bool `json:"tierzero"`
+	// Relationship Counts
+	Computers int `json:"computers"`
+	OUs       int `json:"ous"`
+	Users     int `json:"users"`
 }
 
 // OU represents a BloodHound OU object.
 type OU struct {
-	Name              string      `json:"name"`
-	ObjectID          string      `json:"objectid"`
-	ObjectType        string      `json:"object_type"`
-	DistinguishedName string      `json:"distinguishedname"`
-	WhenCreated       interface{} `json:"whencreated"`
-	Computers         int         `json:"computers"`
-	GPOs              int         `json:"gpos"`
-	Groups            int         `json:"groups"`
-	Users             int         `json:"users"`
-	IsACLProtected    bool        `json:"isaclprotected"`
-	LastCollected     string      `json:"lastcollected"`
-	LastSeen          string      `json:"lastseen"`
-	ObjectCategory    string      `json:"objectcategory"`
-	WhenChanged       int64       `json:"whenchanged"`
+	BaseEntity
+	BlocksInheritance bool     `json:"blocksinheritance"`
+	ObjectCategory    string   `json:"objectcategory"`
+	LastCollected     JsonTime `json:"lastcollected"`
+	WhenChanged       JsonTime `json:"whenchanged"`
+	// Relationship Counts
+	Computers int `json:"computers"`
+	GPOs      int `json:"gpos"`
+	Groups    int `json:"groups"`
+	Users     int `json:"users"`
 }
 
 // BaseAzureEntity represents the common properties for all Azure objects.
 type BaseAzureEntity struct {
-	ObjectID   string `json:"objectid"`
-	Name       string `json:"name"`
-	SystemTags string `json:"system_tags"`
-	ObjectType string `json:"type"`
+	ObjectID    string   `json:"objectid"`
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayname"`
+	Description string   `json:"description"`
+	TenantID    string   `json:"tenantid"`
+	WhenCreated JsonTime `json:"whencreated"`
+	LastSeen    JsonTime `json:"lastseen"`
+	SystemTags  TagSet   `json:"system_tags"`
+	ObjectType  string   `json:"type"`
+	// Extra holds the properties that have no dedicated field.
+	Extra map[string]any `json:"-"`
 }
 
 // AzureUser represents a BloodHound Azure User object.
 type AzureUser struct {
 	BaseAzureEntity
-	UserPrincipalName string `json:"userprincipalname"`
-	Enabled           bool   `json:"enabled"`
+	UserPrincipalName string   `json:"userprincipalname"`
+	Enabled           bool     `json:"enabled"`
+	Mail              string   `json:"mail"`
+	Title             string   `json:"title"`
+	UserType          string   `json:"usertype"`
+	OnPremID          string   `json:"onpremid"`
+	OnPremSyncEnabled bool     `json:"onpremsyncenabled"`
+	PwdLastSet        JsonTime `json:"pwdlastset"`
 }
 
 // AzureGroup represents a BloodHound Azure Group object.
 type AzureGroup struct {
 	BaseAzureEntity
+	Mail               string `json:"mail"`
+	SecurityEnabled    bool   `json:"securityenabled"`
+	IsAssignableToRole bool   `json:"isassignabletorole"`
+	OnPremID           string `json:"onpremid"`
+	OnPremSyncEnabled  bool   `json:"onpremsyncenabled"`
+	SecurityIdentifier string `json:"securityidentifier"`
 }
 
 // AzureVM represents a BloodHound Azure VM object.
@@ -305,6 +366,109 @@ type AzureTenant struct {
 	BaseAzureEntity
 }
 
+// AzureServicePrincipal represents a BloodHound Azure service principal object.
+type AzureServicePrincipal struct {
+	BaseAzureEntity
+	AppID                  string `json:"appid"`
+	AppDisplayName         string `json:"appdisplayname"`
+	AppDescription         string `json:"appdescription"`
+	AppOwnerOrganizationID string `json:"appownerorganizationid"`
+	ServicePrincipalType   string `json:"serviceprincipaltype"`
+	Enabled                bool   `json:"enabled"`
+}
+
+// AzureApp represents a BloodHound Azure application registration object.
+type AzureApp struct {
+	BaseAzureEntity
+	AppID           string `json:"appid"`
+	PublisherDomain string `json:"publisherdomain"`
+	SignInAudience  string `json:"signinaudience"`
+}
+
+// AzureDevice represents a BloodHound Azure device object.
+type AzureDevice struct {
+	BaseAzureEntity
+	DeviceID               string `json:"deviceid"`
+	OperatingSystem        string `json:"operatingsystem"`
+	OperatingSystemVersion string `json:"operatingsystemversion"`
+	TrustType              string `json:"trusttype"`
+	MDMAppID               string `json:"mdmappid"`
+}
+
+// AzureRole represents a BloodHound Azure role object.
+type AzureRole struct {
+	BaseAzureEntity
+	RoleTemplateID string `json:"roletemplateid"`
+	IsBuiltIn      bool   `json:"isbuiltin"`
+	Enabled        bool   `json:"enabled"`
+}
+
+// AzureManagementGroup represents a BloodHound Azure management group object.
+type AzureManagementGroup struct {
+	BaseAzureEntity
+}
+
+// AzureSubscription represents a BloodHound Azure subscription object.
+type AzureSubscription struct {
+	BaseAzureEntity
+}
+
+// AzureResourceGroup represents a BloodHound Azure resource group object.
+type AzureResourceGroup struct {
+	BaseAzureEntity
+}
+
+// AzureKeyVault represents a BloodHound Azure key vault object.
+type AzureKeyVault struct {
+	BaseAzureEntity
+	EnableRBACAuthorization bool `json:"enablerbacauthorization"`
+}
+
+// AzureAutomationAccount represents a BloodHound Azure automation account object.
+type AzureAutomationAccount struct {
+	BaseAzureEntity
+}
+
+// AzureContainerRegistry represents a BloodHound Azure container registry object.
+type AzureContainerRegistry struct {
+	BaseAzureEntity
+}
+
+// AzureFunctionApp represents a BloodHound Azure function app object.
+type AzureFunctionApp struct {
+	BaseAzureEntity
+}
+
+// AzureLogicApp represents a BloodHound Azure logic app object.
+type AzureLogicApp struct {
+	BaseAzureEntity
+}
+
+// AzureWebApp represents a BloodHound Azure web app object.
+type AzureWebApp struct {
+	BaseAzureEntity
+}
+
+// AzureManagedCluster represents a BloodHound Azure managed (AKS) cluster object.
+type AzureManagedCluster struct {
+	BaseAzureEntity
+	// NodeResourceGroupID is the Object ID of the resource group holding the cluster's nodes.
+	NodeResourceGroupID string `json:"noderesourcegroupid"`
+}
+
+// AzureVMScaleSet represents a BloodHound Azure VM scale set object.
+type AzureVMScaleSet struct {
+	BaseAzureEntity
+}
+
+// AzureRelatedEntitiesResponse wraps a page of entities related to an Azure entity.
+type AzureRelatedEntitiesResponse struct {
+	Count int                   `json:"count"`
+	Limit int                   `json:"limit"`
+	Skip  int                   `json:"skip"`
+	Data  []GraphNodeProperties `json:"data"`
+}
+
 // EntityAdmin represents a principal with admin rights to another entity.
 type EntityAdmin struct {
 	Name       string `json:"name"`
@@ -430,7 +594,7 @@ type SearchResult struct {
 	ObjectID   string `json:"objectid"`
 	Name       string `json:"name"`
 	ObjectType string `json:"type"`
-	SystemTags string `json:"system_tags"`
+	SystemTags TagSet `json:"system_tags"`
 }
 
 // SearchResponse wraps the response from the search endpoint.
@@ -455,22 +619,81 @@ type AttackPathsResponse struct {
 }
 
 // AttackPathFinding represents a single attack path finding.
+// List findings (e.g. Kerberoastable Tier Zero users) populate the Principal
+// fields; relationship findings (e.g. non-Tier Zero principals with GenericAll
+// on Tier Zero) populate the FromPrincipal and ToPrincipal fields instead.
+// The server encodes the finding fields in PascalCase and the row fields (id,
+// created_at, updated_at) in snake_case.
 type AttackPathFinding struct {
-	Finding json.RawMessage `json:"finding"`
+	ID                 int64                  `json:"id"`
+	Finding            string                 `json:"Finding"`
+	DomainSID          string                 `json:"DomainSID"`
+	Principal          string                 `json:"Principal"`
+	PrincipalKind      string                 `json:"PrincipalKind"`
+	PrincipalProps     map[string]interface{} `json:"Props"`
+	FromPrincipal      string                 `json:"FromPrincipal"`
+	FromPrincipalKind  string                 `json:"FromPrincipalKind"`
+	FromPrincipalProps map[string]interface{} `json:"FromPrincipalProps"`
+	ToPrincipal        string                 `json:"ToPrincipal"`
+	ToPrincipalKind    string                 `json:"ToPrincipalKind"`
+	ToPrincipalProps   map[string]interface{} `json:"ToPrincipalProps"`
+	RelProps           map[string]interface{} `json:"RelProps"`
+	Severity           FindingSeverity        `json:"Severity"`
+	ImpactPercentage   float64                `json:"ImpactPercentage"`
+	ImpactCount        int                    `json:"ImpactCount"`
+	ExposurePercentage float64                `json:"ExposurePercentage"`
+	ExposureCount      int                    `json:"ExposureCount"`
+	// Accepted is set when the risk has been accepted, with or without an expiry.
+	Accepted      bool      `json:"Accepted"`
+	AcceptedUntil time.Time `json:"AcceptedUntil"`
+	AcceptedBy    string    `json:"AcceptedBy"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// IsAccepted reports whether the finding's risk is accepted at the given time: either
+// until a later time or indefinitely.
+func (f AttackPathFinding) IsAccepted(at time.Time) bool {
+	if !f.AcceptedUntil.IsZero() {
+		return f.AcceptedUntil.After(at)
+	}
+	return f.Accepted
+}
+
+// IsAcceptedIndefinitely reports whether the finding's risk is accepted without an expiry.
+func (f AttackPathFinding) IsAcceptedIndefinitely() bool {
+	return f.Accepted && f.AcceptedUntil.IsZero()
 }
 
-// AttackPathFindingsResponse wraps a list of attack path findings.
+// RiskAcceptanceRequest is the payload for accepting or unaccepting the risk of a finding.
+type RiskAcceptanceRequest struct {
+	RiskType    string     `json:"risk_type"`
+	Accepted    bool       `json:"accepted"`
+	AcceptUntil *time.Time `json:"accept_until,omitempty"`
+}
+
+// AttackPathFindingsResponse wraps a page of attack path findings.
 type AttackPathFindingsResponse struct {
-	Data json.RawMessage `json:"data"`
+	Count int                 `json:"count"`
+	Limit int                 `json:"limit"`
+	Skip  int                 `json:"skip"`
+	Data  []AttackPathFinding `json:"data"`
+}
+
+// FindingSparklinePoint is a single point of a finding's trend over time.
+type FindingSparklinePoint struct {
+	Finding            string    `json:"finding"`
+	DomainSID          string    `json:"domain_sid"`
+	CompositeRisk      float64   `json:"CompositeRisk"`
+	FindingCount       int       `json:"FindingCount"`
+	ImpactedAssetCount int       `json:"ImpactedAssetCount"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 // Container represents a container in BloodHound.
 type Container struct {
-	Name              string      `json:"name"`
-	ObjectID          string      `json:"objectid"`
-	ObjectType        string      `json:"object_type"`
-	DistinguishedName string      `json:"distinguishedname"`
-	WhenCreated       interface{} `json:"whencreated"`
+	BaseEntity
 }
 
 // DCSyncer represents a principal with DCSync rights.
@@ -523,10 +746,12 @@ type ForeignPrincipalsResponse struct {
 
 // GraphNodeProperties represents the properties of a node in a graph response.
 type GraphNodeProperties struct {
-	Name       string `json:"label"`
-	Kind       string `json:"kind"`
-	ObjectID   string `json:"objectId"`
-	SystemTags string `json:"system_tags"`
+	Name       string                 `json:"label"`
+	Kind       string                 `json:"kind"`
+	Kinds      []string               `json:"kinds"`
+	ObjectID   string                 `json:"objectId"`
+	SystemTags TagSet                 `json:"system_tags"`
+	Properties map[string]interface{} `json:"properties"`
 }
 
 // GraphEdge represents an edge in a graph response.
@@ -648,3 +873,203 @@ type AvailableDomain struct {
 	ID         string `json:"id"`
 	Collected  bool   `json:"collected"`
 }
+
+// AssetGroupMember represents a single object selected into an asset group.
+type AssetGroupMember struct {
+	AssetGroupID    int      `json:"asset_group_id"`
+	ObjectID        string   `json:"object_id"`
+	PrimaryKind     string   `json:"primary_kind"`
+	Kinds           []string `json:"kinds"`
+	EnvironmentID   string   `json:"environment_id"`
+	EnvironmentKind string   `json:"environment_kind"`
+	Name            string   `json:"name"`
+	CustomMember    bool     `json:"custom_member"`
+}
+
+// AssetGroupMembersResponse wraps a page of asset group members.
+type AssetGroupMembersResponse struct {
+	Count int `json:"count"`
+	Limit int `json:"limit"`
+	Skip  int `json:"skip"`
+	Data  struct {
+		Members []AssetGroupMember `json:"members"`
+	} `json:"data"`
+}
+
+// ADCSEntity holds the properties shared by every ADCS node kind.
+type ADCSEntity struct {
+	BaseEntity
+	// Relationship Counts
+	Controllers int `json:"controllers"`
+}
+
+// CertificateAuthority holds the certificate properties shared by AIA, root and enterprise CAs.
+type CertificateAuthority struct {
+	ADCSEntity
+	CertName                  string   `json:"certname"`
+	CertThumbprint            string   `json:"certthumbprint"`
+	CertChain                 []string `json:"certchain"`
+	HasBasicConstraints       bool     `json:"hasbasicconstraints"`
+	BasicConstraintPathLength int      `json:"basicconstraintpathlength"`
+}
+
+// AIACA represents a BloodHound AIACA (Authority Information Access CA) object.
+type AIACA struct {
+	CertificateAuthority
+	CrossCertificatePair    []string `json:"crosscertificatepair"`
+	HasCrossCertificatePair bool     `json:"hascrosscertificatepair"`
+}
+
+// RootCA represents a BloodHound RootCA object.
+type RootCA struct {
+	CertificateAuthority
+}
+
+// EnterpriseCA represents a BloodHound EnterpriseCA object.
+type EnterpriseCA struct {
+	CertificateAuthority
+	CAName                               string   `json:"caname"`
+	DNSHostName                          string   `json:"dnshostname"`
+	CASecurityCollected                  bool     `json:"casecuritycollected"`
+	EnrollmentAgentRestrictionsCollected bool     `json:"enrollmentagentrestrictionscollected"`
+	HasEnrollmentAgentRestrictions       bool     `json:"hasenrollmentagentrestrictions"`
+	IsUserSpecifiesSANEnabledCollected   bool     `json:"isuserspecifiessanenabledcollected"`
+	IsUserSpecifiesSANEnabled            bool     `json:"isuserspecifiessanenabled"`
+	RoleSeparationEnabledCollected       bool     `json:"roleseparationenabledcollected"`
+	RoleSeparationEnabled                bool     `json:"roleseparationenabled"`
+	UnresolvedPublishedTemplates         []string `json:"unresolvedpublishedtemplates"`
+}
+
+// NTAuthStore represents a BloodHound NTAuthStore object.
+type NTAuthStore struct {
+	ADCSEntity
+	CertThumbprints []string `json:"certthumbprints"`
+}
+
+// CertTemplate represents a BloodHound CertTemplate object.
+type CertTemplate struct {
+	ADCSEntity
+	DisplayName                   string   `json:"displayname"`
+	OID                           string   `json:"oid"`
+	SchemaVersion                 int      `json:"schemaversion"`
+	ValidityPeriod                string   `json:"validityperiod"`
+	RenewalPeriod                 string   `json:"renewalperiod"`
+	AuthenticationEnabled         bool     `json:"authenticationenabled"`
+	SchannelAuthenticationEnabled bool     `json:"schannelauthenticationenabled"`
+	EnrolleeSuppliesSubject       bool     `json:"enrolleesuppliessubject"`
+	RequiresManagerApproval       bool     `json:"requiresmanagerapproval"`
+	NoSecurityExtension           bool     `json:"nosecurityextension"`
+	AuthorizedSignatures          int      `json:"authorizedsignatures"`
+	SubjectAltRequireUPN          bool     `json:"subjectaltrequireupn"`
+	SubjectAltRequireDNS          bool     `json:"subjectaltrequiredns"`
+	SubjectAltRequireDomainDNS    bool     `json:"subjectaltrequiredomaindns"`
+	SubjectAltRequireEmail        bool     `json:"subjectaltrequireemail"`
+	SubjectAltRequireSPN          bool     `json:"subjectaltrequirespn"`
+	SubjectRequireEmail           bool     `json:"subjectrequireemail"`
+	EKUs                          []string `json:"ekus"`
+	EffectiveEKUs                 []string `json:"effectiveekus"`
+	CertificateApplicationPolicy  []string `json:"certificateapplicationpolicy"`
+	ApplicationPolicies           []string `json:"applicationpolicies"`
+	IssuancePolicies              []string `json:"issuancepolicies"`
+	CertificatePolicy             []string `json:"certificatepolicy"`
+}
+
+// IssuancePolicy represents a BloodHound IssuancePolicy object.
+type IssuancePolicy struct {
+	ADCSEntity
+	DisplayName     string `json:"displayname"`
+	CertTemplateOID string `json:"certtemplateoid"`
+	GroupLink       string `json:"grouplink"`
+}
+
+// RelatedEntity is a single entry of a relationship list endpoint
+// (e.g. /api/v2/computers/{id}/admin-users).
+type RelatedEntity struct {
+	Name       string `json:"name"`
+	ObjectID   string `json:"objectID"`
+	ObjectType string `json:"label"`
+	IsTierZero bool   `json:"is_tier_zero"`
+}
+
+// decodeRelatedEntities decodes the raw data of a relationship list response.
+func decodeRelatedEntities(data json.RawMessage) ([]RelatedEntity, error) {
+	var entities []RelatedEntity
+	if len(data) == 0 || string(data) == "null" {
+		return entities, nil
+	}
+	if err := json.Unmarshal(data, &entities); err != nil {
+		return nil, fmt.Errorf("failed to decode related entities: %w", err)
+	}
+	return entities, nil
+}
+
+// Entities decodes the admins in the response.
+func (r EntityAdminsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the sessions in the response.
+func (r SessionsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the privileges in the response.
+func (r PrivilegesResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the constrained delegations in the response.
+func (r ConstrainedDelegationsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the group memberships in the response.
+func (r GroupMembershipsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the controllers in the response.
+func (r ControllersResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the controllables in the response.
+func (r ControllablesResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the computers in the response.
+func (r ComputersResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the OUs in the response.
+func (r OUsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the groups in the response.
+func (r GroupsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the GPOs in the response.
+func (r GPOsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the foreign principals in the response.
+func (r ForeignPrincipalsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the domain trusts in the response.
+func (r DomainTrustsResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
+
+// Entities decodes the AD users in the response. Only valid for graph user lists
+// (e.g. GetDomainUsers), not for BloodHound application users.
+func (r UsersResponse) Entities() ([]RelatedEntity, error) {
+	return decodeRelatedEntities(r.Data)
+}
@@ -0,0 +1,182 @@
+package bloodhound
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJsonTimeRoundTrip(t *testing.T) {
+	for _, input := range []string{`1700000000`, `1700000000.5`, `"2023-11-14T22:13:20Z"`} {
+		var jt JsonTime
+		if err := json.Unmarshal([]byte(input), &jt); err != nil {
+			t.Fatalf("Unmarshal(%s) returned an error: %v", input, err)
+		}
+		if got := jt.Time().Unix(); got != 1700000000 {
+			t.Errorf("Unmarshal(%s) = %d, want 1700000000", input, got)
+		}
+
+		encoded, err := json.Marshal(jt)
+		if err != nil {
+			t.Fatalf("Marshal returned an error: %v", err)
+		}
+		var decoded JsonTime
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s) returned an error: %v", encoded, err)
+		}
+		if !decoded.Time().Equal(jt.Time()) {
+			t.Errorf("round trip of %s gave %v, want %v", input, decoded.Time(), jt.Time())
+		}
+	}
+
+	var unset JsonTime
+	if err := json.Unmarshal([]byte(`-1`), &unset); err != nil || !unset.IsZero() {
+		t.Errorf("expected -1 to decode to a zero time, got %v (err %v)", unset.Time(), err)
+	}
+	if encoded, _ := json.Marshal(unset); string(encoded) != "null" {
+		t.Errorf("expected a zero time to encode as null, got %s", encoded)
+	}
+}
+
+func TestEntityExtraProperties(t *testing.T) {
+	data := `{
+		"objectid": "S-1-5-21-1-1104",
+		"name": "ALICE@CORP.LOCAL",
+		"domain": "CORP.LOCAL",
+		"enabled": true,
+		"lastseen": "2024-05-01T10:00:00Z",
+		"pwdlastset": 1700000000,
+		"adminRights": 3,
+		"customproperty": "value"
+	}`
+
+	var user ADUser
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if user.Name != "ALICE@CORP.LOCAL" || user.Domain != "CORP.LOCAL" || !user.Enabled || user.AdminRights != 3 {
+		t.Errorf("known properties were not decoded: %+v", user)
+	}
+	if !user.LastSeen.Time().Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected lastseen: %v", user.LastSeen.Time())
+	}
+	if len(user.Extra) != 1 || user.Extra["customproperty"] != "value" {
+		t.Errorf("expected only customproperty in Extra, got %v", user.Extra)
+	}
+
+	encoded, err := json.Marshal(user)
+	if err != nil {
+		t.Fatalf("Marshal returned an error: %v", err)
+	}
+	var decoded ADUser
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal(%s) returned an error: %v", encoded, err)
+	}
+	if decoded.Extra["customproperty"] != "value" || decoded.Name != user.Name || decoded.AdminRights != 3 {
+		t.Errorf("round trip lost properties: %s", encoded)
+	}
+
+	var domain Domain
+	if err := json.Unmarshal([]byte(`{"name": "CORP.LOCAL", "masteredby": ["S-1-5-21-1-1000"], "msds-masteredby": ["S-1-5-21-1-1000", "S-1-5-21-1-1001"]}`), &domain); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if len(domain.MasteredBy) != 1 || domain.MasteredBy[0] != "S-1-5-21-1-1000" || len(domain.MSDSMasteredBy) != 2 || len(domain.Extra) != 0 {
+		t.Errorf("unexpected mastered by: %v, %v (extra %v)", domain.MasteredBy, domain.MSDSMasteredBy, domain.Extra)
+	}
+}
+
+func TestTagSet(t *testing.T) {
+	var result SearchResult
+	if err := json.Unmarshal([]byte(`{"objectid": "S-1-5-21-1-512", "system_tags": "admin_tier_0 owned"}`), &result); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if !result.IsTierZero() || !result.IsOwned() || result.HasTag("other") {
+		t.Errorf("unexpected tags: %v", result.SystemTags)
+	}
+
+	encoded, err := json.Marshal(result.SystemTags)
+	if err != nil || string(encoded) != `"admin_tier_0 owned"` {
+		t.Errorf("Marshal = %s (err %v), want the space-separated form", encoded, err)
+	}
+
+	node := GraphNodeProperties{Kind: "User", Kinds: []string{"Base", "User", KindTagTierZero}}
+	if !node.IsTierZero() || !node.HasKind("Base") || node.IsOwned() {
+		t.Errorf("unexpected kind checks for %v", node.Kinds)
+	}
+}
+
+func TestAzureResourceEntities(t *testing.T) {
+	const subscriptionID = "6B1F0C41-3E6E-4B3A-9E2B-0B1B2C3D4E5F"
+	const subscription = "/SUBSCRIPTIONS/" + subscriptionID
+	const resourceGroup = subscription + "/RESOURCEGROUPS/PROD-RG"
+	resource := func(provider, resourceType, name string) (string, AzureResourceID) {
+		return resourceGroup + "/PROVIDERS/" + provider + "/" + resourceType + "/" + name,
+			AzureResourceID{SubscriptionID: subscriptionID, ResourceGroup: "PROD-RG", Provider: provider, ResourceType: resourceType, Name: name}
+	}
+	type row struct {
+		kind     string
+		objectID string
+		want     AzureResourceID
+	}
+	rowFor := func(kind, provider, resourceType, name string) row {
+		objectID, want := resource(provider, resourceType, name)
+		return row{kind, objectID, want}
+	}
+	tests := []row{
+		{"AZManagementGroup", "/PROVIDERS/MICROSOFT.MANAGEMENT/MANAGEMENTGROUPS/ROOT", AzureResourceID{Provider: "MICROSOFT.MANAGEMENT", ResourceType: "MANAGEMENTGROUPS", Name: "ROOT"}},
+		{"AZSubscription", subscription, AzureResourceID{SubscriptionID: subscriptionID, Name: subscriptionID}},
+		{"AZResourceGroup", resourceGroup, AzureResourceID{SubscriptionID: subscriptionID, ResourceGroup: "PROD-RG", Name: "PROD-RG"}},
+		rowFor("AZAutomationAccount", "MICROSOFT.AUTOMATION", "AUTOMATIONACCOUNTS", "RUNBOOKS"),
+		rowFor("AZContainerRegistry", "MICROSOFT.CONTAINERREGISTRY", "REGISTRIES", "PRODACR"),
+		rowFor("AZFunctionApp", "MICROSOFT.WEB", "SITES", "PROD-FUNC"),
+		rowFor("AZLogicApp", "MICROSOFT.LOGIC", "WORKFLOWS", "PROD-FLOW"),
+		rowFor("AZWebApp", "MICROSOFT.WEB", "SITES", "PROD-WEB"),
+		rowFor("AZManagedCluster", "MICROSOFT.CONTAINERSERVICE", "MANAGEDCLUSTERS", "PROD-AKS"),
+		rowFor("AZVMScaleSet", "MICROSOFT.COMPUTE", "VIRTUALMACHINESCALESETS", "PROD-VMSS"),
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			data := fmt.Sprintf(`{
+				"objectid": %q,
+				"name": "%s",
+				"tenantid": "0F1E2D3C-4B5A-6978-8796-A5B4C3D2E1F0",
+				"lastseen": "2024-05-01T10:00:00Z",
+				"system_tags": "admin_tier_0",
+				"noderesourcegroupid": "%s/RESOURCEGROUPS/MC_PROD-RG",
+				"customproperty": "value"
+			}`, tt.objectID, tt.want.Name, subscription)
+
+			value := entityTypes[tt.kind]()
+			if err := json.Unmarshal([]byte(data), value); err != nil {
+				t.Fatalf("Unmarshal returned an error: %v", err)
+			}
+			entity := reflect.ValueOf(value).Elem().FieldByName("BaseAzureEntity").Interface().(BaseAzureEntity)
+			if entity.ObjectID != tt.objectID || entity.Name != tt.want.Name || entity.TenantID == "" || entity.LastSeen.IsZero() || !entity.IsTierZero() {
+				t.Errorf("common properties were not decoded: %+v", entity)
+			}
+			wantExtra := 2
+			if cluster, ok := value.(*AzureManagedCluster); ok {
+				if cluster.NodeResourceGroupID != subscription+"/RESOURCEGROUPS/MC_PROD-RG" {
+					t.Errorf("unexpected node resource group: %q", cluster.NodeResourceGroupID)
+				}
+				wantExtra = 1
+			}
+			if len(entity.Extra) != wantExtra || entity.Extra["customproperty"] != "value" {
+				t.Errorf("unexpected Extra: %v", entity.Extra)
+			}
+
+			got, ok := entity.ResourceID()
+			if !ok || got != tt.want {
+				t.Errorf("ResourceID() = %+v, %v, want %+v", got, ok, tt.want)
+			}
+		})
+	}
+
+	for _, id := range []string{"", "6B1F0C41-3E6E-4B3A-9E2B-0B1B2C3D4E5F", "/SUBSCRIPTIONS", "/UNKNOWN/X"} {
+		if got, ok := ParseAzureResourceID(id); ok {
+			t.Errorf("ParseAzureResourceID(%q) = %+v, want false", id, got)
+		}
+	}
+}
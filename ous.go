@@ -1,29 +1,15 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 )
 
 // GetOU fetches a single OU by its Object ID (GUID).
 func (c *Client) GetOU(objectID string) (*OU, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/ous/", objectID)
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get ou failed with status code: %d", resp.StatusCode)
-	}
 
 	var response struct {
 		Data struct {
@@ -34,8 +20,8 @@ func (c *Client) GetOU(objectID string) (*OU, error) {
 			Users     int `json:"users"`
 		} `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode get ou response: %w", err)
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
 	}
 	ou := response.Data.Props
 	ou.ObjectType = "OU"
@@ -48,18 +34,11 @@ func (c *Client) GetOU(objectID string) (*OU, error) {
 
 // GetOUByName fetches a single OU by its name.
 func (c *Client) GetOUByName(ouName string) (*OU, error) {
-	searchResponse, err := c.Search(ouName, "OU", 0)
+	objectID, err := c.resolveObjectID("OU", ouName)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, result := range searchResponse.Data {
-		if strings.EqualFold(result.Name, ouName) {
-			return c.GetOU(result.ObjectID)
-		}
-	}
-
-	return nil, fmt.Errorf("ou not found: %s", ouName)
+	return c.GetOU(objectID)
 }
 
 // GetOUGroups fetches the groups in a given OU.
@@ -71,19 +50,8 @@ func (c *Client) GetOUGroups(objectID string, limit int) (GroupsResponse, error)
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetOUComputers fetches the computers in a given OU.
@@ -95,19 +63,8 @@ func (c *Client) GetOUComputers(objectID string, limit int) (ComputersResponse,
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetOUUsers fetches the users in a given OU.
@@ -119,19 +76,8 @@ func (c *Client) GetOUUsers(objectID string, limit int) (UsersResponse, error) {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
 }
 
 // GetOuGPOs fetches the GPOs linked to a given OU.
@@ -143,17 +89,11 @@ func (c *Client) GetOuGPOs(objectID string, limit int) (GPOsResponse, error) {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
 	apiUrl.RawQuery = params.Encode()
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	resp, err := c.do(req, nil)
-	if err != nil {
-		return rawResponse, err
-	}
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return rawResponse, err
-	}
-	return rawResponse, nil
+	err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse)
+	return rawResponse, err
+}
+
+// GetOUGPOInheritance fetches the GPOs that apply to a given OU, including inherited and enforced links.
+func (c *Client) GetOUGPOInheritance(objectID string) ([]InheritedGPO, error) {
+	return c.GetGPOInheritance(objectID)
 }
@@ -0,0 +1,186 @@
+package bloodhound
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Hop is a single step of a path, with the metadata of the edge it traverses.
+type Hop struct {
+	Index int
+	From  PathNode
+	To    PathNode
+	Kind  EdgeKind
+	Edge  GraphEdge
+}
+
+// IsACL reports whether the edge was derived from an ACE.
+func (h Hop) IsACL() bool {
+	value, _ := h.Edge.Properties["isacl"].(bool)
+	return value
+}
+
+// IsInherited reports whether the edge was derived from an inherited ACE.
+func (h Hop) IsInherited() bool {
+	value, _ := h.Edge.Properties["isinherited"].(bool)
+	return value
+}
+
+// Hops returns the steps of the path in order.
+func (p Path) Hops() []Hop {
+	hops := make([]Hop, 0, len(p.Edges))
+	for i, edge := range p.Edges {
+		hops = append(hops, Hop{Index: i, From: p.Nodes[i], To: p.Nodes[i+1], Kind: edge.EdgeKind(), Edge: edge})
+	}
+	return hops
+}
+
+// String renders the path as a chain, e.g. "A -MemberOf-> B -GenericAll-> C".
+func (p Path) String() string {
+	if len(p.Nodes) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(nodeLabel(p.Nodes[0]))
+	for _, hop := range p.Hops() {
+		fmt.Fprintf(&b, " -%s-> %s", hop.Kind, nodeLabel(hop.To))
+	}
+	return b.String()
+}
+
+// EdgeKinds returns the kinds of the edges along the path, in order.
+func (p Path) EdgeKinds() []EdgeKind {
+	kinds := make([]EdgeKind, 0, len(p.Edges))
+	for _, edge := range p.Edges {
+		kinds = append(kinds, edge.EdgeKind())
+	}
+	return kinds
+}
+
+func nodeLabel(node PathNode) string {
+	switch {
+	case node.Name != "":
+		return node.Name
+	case node.ObjectID != "":
+		return node.ObjectID
+	default:
+		return node.ID
+	}
+}
+
+// Branch is a node at which a graph response splits into, or merges from, several paths.
+type Branch struct {
+	Node     PathNode
+	Outgoing []GraphEdge
+	Incoming []GraphEdge
+}
+
+// Paths reads the ordered paths from the start to the end object out of a graph response,
+// shortest first. Empty object IDs are inferred: the start is the only node without
+// incoming edges and the end the only node without outgoing edges.
+func (d ShortestPathData) Paths(startObjectID, endObjectID string) ([]Path, error) {
+	return reconstructPaths(d.Nodes, d.Edges, startObjectID, endObjectID)
+}
+
+// Path returns the single path in a graph response. It fails when the response branches
+// into several paths; use Paths to get all of them.
+func (d ShortestPathData) Path(startObjectID, endObjectID string) (*Path, error) {
+	paths, err := d.Paths(startObjectID, endObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) > 1 {
+		return nil, fmt.Errorf("graph contains %d paths, not one", len(paths))
+	}
+	return &paths[0], nil
+}
+
+// Branches returns the nodes at which the graph response splits or merges.
+func (d ShortestPathData) Branches() []Branch {
+	return findBranches(d.Nodes, d.Edges)
+}
+
+// Paths reads the ordered paths from the start to the end object out of a Cypher graph
+// result, shortest first. See ShortestPathData.Paths.
+func (d CypherResponseData) Paths(startObjectID, endObjectID string) ([]Path, error) {
+	return reconstructPaths(d.Nodes, d.Edges, startObjectID, endObjectID)
+}
+
+// Branches returns the nodes at which the Cypher graph result splits or merges.
+func (d CypherResponseData) Branches() []Branch {
+	return findBranches(d.Nodes, d.Edges)
+}
+
+func reconstructPaths(nodes map[string]GraphNodeProperties, edges []GraphEdge, startObjectID, endObjectID string) ([]Path, error) {
+	if startObjectID == "" || endObjectID == "" {
+		inferredStart, inferredEnd, err := pathEndpoints(nodes, edges)
+		if err != nil {
+			return nil, err
+		}
+		if startObjectID == "" {
+			startObjectID = inferredStart
+		}
+		if endObjectID == "" {
+			endObjectID = inferredEnd
+		}
+	}
+
+	paths := pathsBetween(nodes, edges, startObjectID, endObjectID)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("path from %s to %s %w", startObjectID, endObjectID, ErrNotFound)
+	}
+	return paths, nil
+}
+
+// pathEndpoints returns the Object IDs of the only source and the only sink of a graph.
+func pathEndpoints(nodes map[string]GraphNodeProperties, edges []GraphEdge) (string, string, error) {
+	hasIncoming, hasOutgoing := map[string]bool{}, map[string]bool{}
+	for _, edge := range edges {
+		hasOutgoing[edge.Source] = true
+		hasIncoming[edge.Target] = true
+	}
+
+	var sources, sinks []string
+	for id := range nodes {
+		if !hasIncoming[id] && hasOutgoing[id] {
+			sources = append(sources, id)
+		}
+		if !hasOutgoing[id] && hasIncoming[id] {
+			sinks = append(sinks, id)
+		}
+	}
+	if len(sources) != 1 || len(sinks) != 1 {
+		return "", "", fmt.Errorf("cannot infer path endpoints: graph has %d start and %d end candidates", len(sources), len(sinks))
+	}
+	return objectIDOf(nodes[sources[0]]), objectIDOf(nodes[sinks[0]]), nil
+}
+
+func objectIDOf(node GraphNodeProperties) string {
+	if node.ObjectID != "" {
+		return node.ObjectID
+	}
+	value, _ := node.Properties["objectid"].(string)
+	return value
+}
+
+func findBranches(nodes map[string]GraphNodeProperties, edges []GraphEdge) []Branch {
+	outgoing, incoming := map[string][]GraphEdge{}, map[string][]GraphEdge{}
+	for _, edge := range edges {
+		outgoing[edge.Source] = append(outgoing[edge.Source], edge)
+		incoming[edge.Target] = append(incoming[edge.Target], edge)
+	}
+
+	var branches []Branch
+	for id, node := range nodes {
+		if len(outgoing[id]) > 1 || len(incoming[id]) > 1 {
+			branches = append(branches, Branch{
+				Node:     PathNode{ID: id, GraphNodeProperties: node},
+				Outgoing: outgoing[id],
+				Incoming: incoming[id],
+			})
+		}
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Node.ID < branches[j].Node.ID })
+	return branches
+}
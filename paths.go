@@ -0,0 +1,332 @@
+package bloodhound
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DefaultMaxPathDepth bounds variable-length Cypher path queries when no depth is given.
+const DefaultMaxPathDepth = 10
+
+// maxEnumeratedPaths bounds the number of paths read out of a single graph response.
+const maxEnumeratedPaths = 1000
+
+// PathFilter restricts the edge kinds a path may traverse. When Include is set only
+// those kinds are traversed; kinds in Exclude are never traversed.
+type PathFilter struct {
+	Include []EdgeKind
+	Exclude []EdgeKind
+}
+
+// OnlyEdges returns a filter that traverses only the given edge kinds.
+func OnlyEdges(kinds ...EdgeKind) PathFilter {
+	return PathFilter{Include: kinds}
+}
+
+// ExcludeEdges returns a filter that traverses every edge kind except the given ones.
+func ExcludeEdges(kinds ...EdgeKind) PathFilter {
+	return PathFilter{Exclude: kinds}
+}
+
+// IsZero reports whether the filter allows every edge kind.
+func (f PathFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Allows reports whether the filter lets a path traverse the given edge kind.
+func (f PathFilter) Allows(kind EdgeKind) bool {
+	for _, excluded := range f.Exclude {
+		if excluded == kind {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, included := range f.Include {
+		if included == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the filter in the relationship_kinds syntax of the shortest path
+// endpoint: "in:A,B" or "nin:A,B". It is empty when the filter allows every kind.
+func (f PathFilter) String() string {
+	switch {
+	case f.IsZero():
+		return ""
+	case len(f.Include) == 0:
+		return "nin:" + joinEdgeKinds(f.Exclude, ",")
+	default:
+		return "in:" + joinEdgeKinds(f.kinds(), ",")
+	}
+}
+
+// kinds returns the included kinds that are not excluded.
+func (f PathFilter) kinds() []EdgeKind {
+	var kinds []EdgeKind
+	for _, kind := range f.Include {
+		if f.Allows(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// cypherPattern renders the included kinds as the relationship type list of a Cypher
+// pattern, e.g. ":MemberOf|GenericAll". It is empty when no kinds are included.
+func (f PathFilter) cypherPattern() string {
+	if len(f.Include) == 0 {
+		return ""
+	}
+	return ":" + joinEdgeKinds(f.kinds(), "|")
+}
+
+// cypherPredicate renders the excluded kinds of a filter without included kinds as a
+// condition on the given path variable, e.g.
+// ` AND none(r IN relationships(p) WHERE type(r) IN ["HasSession"])`, so that kinds this
+// package does not know about are still traversed. It is empty otherwise.
+func (f PathFilter) cypherPredicate(path string) string {
+	if len(f.Include) > 0 || len(f.Exclude) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(f.Exclude))
+	for _, kind := range f.Exclude {
+		quoted = append(quoted, cypherString(string(kind)))
+	}
+	return fmt.Sprintf(" AND none(r IN relationships(%s) WHERE type(r) IN [%s])", path, strings.Join(quoted, ", "))
+}
+
+func joinEdgeKinds(kinds []EdgeKind, sep string) string {
+	names := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		names = append(names, string(kind))
+	}
+	return strings.Join(names, sep)
+}
+
+// PathNode is a node on a path, with the graph ID edges refer to it by.
+type PathNode struct {
+	ID string
+	GraphNodeProperties
+}
+
+// Path is an ordered sequence of nodes and the edges between them: Edges[i] leads from
+// Nodes[i] to Nodes[i+1].
+type Path struct {
+	Nodes []PathNode
+	Edges []GraphEdge
+}
+
+// Len returns the number of hops in the path.
+func (p Path) Len() int {
+	return len(p.Edges)
+}
+
+// Start returns the first node of the path.
+func (p Path) Start() PathNode {
+	return p.Nodes[0]
+}
+
+// End returns the last node of the path.
+func (p Path) End() PathNode {
+	return p.Nodes[len(p.Nodes)-1]
+}
+
+// FindShortestPath returns a shortest path between two objects, traversing only the
+// edge kinds the filter allows.
+func (c *Client) FindShortestPath(ctx context.Context, startObjectID, endObjectID string, filter PathFilter) (*Path, error) {
+	response, err := c.getShortestPath(ctx, startObjectID, endObjectID, filter.String())
+	if err != nil {
+		return nil, err
+	}
+	paths, err := response.Data.Paths(startObjectID, endObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &paths[0], nil
+}
+
+// FindAllShortestPaths returns every shortest path between two objects, traversing only
+// the edge kinds the filter allows.
+func (c *Client) FindAllShortestPaths(ctx context.Context, startObjectID, endObjectID string, filter PathFilter) ([]Path, error) {
+	query := fmt.Sprintf("MATCH p = allShortestPaths((s)-[%s*1..]->(t)) WHERE s.objectid = %s AND t.objectid = %s%s RETURN p",
+		filter.cypherPattern(), cypherString(strings.ToUpper(startObjectID)), cypherString(strings.ToUpper(endObjectID)), filter.cypherPredicate("p"))
+	graph, err := c.RunCypherGraph(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return graph.Paths(startObjectID, endObjectID)
+}
+
+// FindKShortestPaths returns up to k paths between two objects, shortest first, of at
+// most maxDepth hops. A maxDepth of zero uses DefaultMaxPathDepth. Each path is read from
+// its own result row, with one request per path, so edges of different paths are never
+// combined. Paths of equal length come back in the order the server returns them.
+// When there is no path the error wraps ErrNotFound.
+func (c *Client) FindKShortestPaths(ctx context.Context, startObjectID, endObjectID string, k, maxDepth int, filter PathFilter) ([]Path, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxPathDepth
+	}
+	query := fmt.Sprintf("MATCH p = (s)-[%s*1..%d]->(t) WHERE s.objectid = %s AND t.objectid = %s%s RETURN p ORDER BY length(p)",
+		filter.cypherPattern(), maxDepth, cypherString(strings.ToUpper(startObjectID)), cypherString(strings.ToUpper(endObjectID)), filter.cypherPredicate("p"))
+
+	var paths []Path
+	seen := map[string]bool{}
+	for row := 0; len(paths) < k; row++ {
+		graph, err := c.RunCypherGraph(ctx, fmt.Sprintf("%s SKIP %d LIMIT 1", query, row))
+		if err != nil {
+			return nil, err
+		}
+		if len(graph.Edges) == 0 {
+			break
+		}
+		path, ok := rowPath(graph.Nodes, graph.Edges, startObjectID, endObjectID)
+		if !ok {
+			return nil, fmt.Errorf("row %d of the path query is not a path from %s to %s", row, startObjectID, endObjectID)
+		}
+		// Rows of equal length may shift between requests; a path is only returned once.
+		if key := pathKey(path); !seen[key] {
+			seen[key] = true
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("path from %s to %s %w", startObjectID, endObjectID, ErrNotFound)
+	}
+	return paths, nil
+}
+
+// rowPath orders the edges of a single path result row into the path from the start to
+// the end object, using every edge once.
+func rowPath(nodes map[string]GraphNodeProperties, edges []GraphEdge, startObjectID, endObjectID string) (Path, bool) {
+	startID, endID := nodeIDForObject(nodes, startObjectID), nodeIDForObject(nodes, endObjectID)
+	if startID == "" || endID == "" {
+		return Path{}, false
+	}
+	used := make([]bool, len(edges))
+	trail := make([]GraphEdge, 0, len(edges))
+	var walk func(id string) bool
+	walk = func(id string) bool {
+		if len(trail) == len(edges) {
+			return id == endID
+		}
+		for i, edge := range edges {
+			if used[i] || edge.Source != id {
+				continue
+			}
+			used[i] = true
+			trail = append(trail, edge)
+			if walk(edge.Target) {
+				return true
+			}
+			trail = trail[:len(trail)-1]
+			used[i] = false
+		}
+		return false
+	}
+	if !walk(startID) {
+		return Path{}, false
+	}
+	return newPath(nodes, startID, trail), true
+}
+
+// pathKey identifies a path by the graph IDs and kinds of its edges.
+func pathKey(p Path) string {
+	var b strings.Builder
+	for _, edge := range p.Edges {
+		fmt.Fprintf(&b, "%s-%s->%s;", edge.Source, edge.EdgeKind(), edge.Target)
+	}
+	return b.String()
+}
+
+// pathsBetween enumerates the simple paths from the node with startObjectID to the node
+// with endObjectID in a graph response, shortest first.
+func pathsBetween(nodes map[string]GraphNodeProperties, edges []GraphEdge, startObjectID, endObjectID string) []Path {
+	startID, endID := nodeIDForObject(nodes, startObjectID), nodeIDForObject(nodes, endObjectID)
+	if startID == "" || endID == "" {
+		return nil
+	}
+	return pathsBetweenIDs(nodes, edges, startID, endID)
+}
+
+// pathsBetweenIDs enumerates the simple paths between two graph IDs, shortest first. The
+// search is deepened one hop at a time, so when there are more than maxEnumeratedPaths
+// paths the ones returned are the shortest.
+func pathsBetweenIDs(nodes map[string]GraphNodeProperties, edges []GraphEdge, startID, endID string) []Path {
+	if startID == endID {
+		return []Path{newPath(nodes, startID, nil)}
+	}
+	outgoing := map[string][]GraphEdge{}
+	for _, edge := range edges {
+		outgoing[edge.Source] = append(outgoing[edge.Source], edge)
+	}
+
+	var paths []Path
+	visited := map[string]bool{startID: true}
+	var trail []GraphEdge
+	// walk collects the paths of exactly depth hops and reports whether a trail reached
+	// that depth without ending at endID, i.e. whether a deeper search can find more.
+	var walk func(id string, depth int) bool
+	walk = func(id string, depth int) bool {
+		if len(paths) >= maxEnumeratedPaths {
+			return false
+		}
+		if id == endID {
+			if len(trail) == depth {
+				paths = append(paths, newPath(nodes, startID, trail))
+			}
+			return false
+		}
+		if len(trail) == depth {
+			return true
+		}
+		deeper := false
+		for _, edge := range outgoing[id] {
+			if visited[edge.Target] {
+				continue
+			}
+			visited[edge.Target] = true
+			trail = append(trail, edge)
+			if walk(edge.Target, depth) {
+				deeper = true
+			}
+			trail = trail[:len(trail)-1]
+			visited[edge.Target] = false
+		}
+		return deeper
+	}
+	for depth := 1; walk(startID, depth); depth++ {
+	}
+	return paths
+}
+
+// nodeIDForObject returns the graph ID of the node with the given Object ID.
+func nodeIDForObject(nodes map[string]GraphNodeProperties, objectID string) string {
+	for id, node := range nodes {
+		if strings.EqualFold(node.ObjectID, objectID) {
+			return id
+		}
+		if value, ok := node.Properties["objectid"].(string); ok && strings.EqualFold(value, objectID) {
+			return id
+		}
+	}
+	return ""
+}
+
+func newPath(nodes map[string]GraphNodeProperties, startID string, edges []GraphEdge) Path {
+	path := Path{
+		Nodes: []PathNode{{ID: startID, GraphNodeProperties: nodes[startID]}},
+		Edges: append([]GraphEdge{}, edges...),
+	}
+	for _, edge := range edges {
+		path.Nodes = append(path.Nodes, PathNode{ID: edge.Target, GraphNodeProperties: nodes[edge.Target]})
+	}
+	return path
+}
@@ -0,0 +1,213 @@
+package bloodhound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPathFilter(t *testing.T) {
+	if got := OnlyEdges(EdgeMemberOf, EdgeGenericAll).String(); got != "in:MemberOf,GenericAll" {
+		t.Errorf("OnlyEdges().String() = %q", got)
+	}
+	if got := ExcludeEdges(EdgeHasSession).String(); got != "nin:HasSession" {
+		t.Errorf("ExcludeEdges().String() = %q", got)
+	}
+	mixed := PathFilter{Include: []EdgeKind{EdgeMemberOf, EdgeHasSession}, Exclude: []EdgeKind{EdgeHasSession}}
+	if got := mixed.String(); got != "in:MemberOf" {
+		t.Errorf("mixed filter String() = %q", got)
+	}
+	if got := mixed.cypherPattern(); got != ":MemberOf" {
+		t.Errorf("mixed filter cypherPattern() = %q", got)
+	}
+	if (PathFilter{}).String() != "" || (PathFilter{}).cypherPattern() != "" || (PathFilter{}).cypherPredicate("p") != "" {
+		t.Error("expected an empty filter to render as nothing")
+	}
+
+	// Exclusions alone must not turn into an allow-list of the known kinds.
+	excluded := ExcludeEdges(EdgeHasSession, EdgeAdminTo)
+	if got := excluded.cypherPattern(); got != "" {
+		t.Errorf("ExcludeEdges().cypherPattern() = %q", got)
+	}
+	if got, want := excluded.cypherPredicate("p"), ` AND none(r IN relationships(p) WHERE type(r) IN ["HasSession", "AdminTo"])`; got != want {
+		t.Errorf("ExcludeEdges().cypherPredicate() = %q, want %q", got, want)
+	}
+	if got := mixed.cypherPredicate("p"); got != "" {
+		t.Errorf("mixed filter cypherPredicate() = %q", got)
+	}
+}
+
+func TestFindKShortestPaths(t *testing.T) {
+	// The two rows cross at C; reading them from one merged graph would also yield the
+	// paths A-B-C-F-D and A-E-C-D, which no row returned.
+	rows := []string{
+		`{"nodes": {"1": {"label": "A", "objectId": "A"}, "2": {"label": "B", "objectId": "B"}, "3": {"label": "C", "objectId": "C"}, "4": {"label": "D", "objectId": "D"}},
+			"edges": [{"source": "3", "target": "4", "kind": "GenericAll"}, {"source": "1", "target": "2", "kind": "MemberOf"}, {"source": "2", "target": "3", "kind": "AdminTo"}]}`,
+		`{"nodes": {"1": {"label": "A", "objectId": "A"}, "5": {"label": "E", "objectId": "E"}, "3": {"label": "C", "objectId": "C"}, "6": {"label": "F", "objectId": "F"}, "4": {"label": "D", "objectId": "D"}},
+			"edges": [{"source": "1", "target": "5", "kind": "MemberOf"}, {"source": "5", "target": "3", "kind": "GenericWrite"}, {"source": "3", "target": "6", "kind": "HasSession"}, {"source": "6", "target": "4", "kind": "DCSync"}]}`,
+	}
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", func(w http.ResponseWriter, r *http.Request) {
+		var query CypherQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			t.Errorf("failed to decode cypher request: %v", err)
+		}
+		queries = append(queries, query.Query)
+		var row int
+		if _, err := fmt.Sscanf(query.Query[strings.LastIndex(query.Query, "SKIP"):], "SKIP %d LIMIT 1", &row); err != nil {
+			t.Errorf("unexpected query %q", query.Query)
+		}
+		if !strings.Contains(query.Query, `s.objectid = "A"`) || row >= len(rows) {
+			writeTestJSON(w, http.StatusNotFound, `{"http_status": 404, "errors": [{"context": "", "message": "resource not found"}]}`)
+			return
+		}
+		writeTestJSON(w, http.StatusOK, `{"data": `+rows[row]+`}`)
+	})
+	client := newTestClient(t, mux)
+
+	paths, err := client.FindKShortestPaths(context.Background(), "a", "D", 3, 0, ExcludeEdges(EdgeCanRDP))
+	if err != nil {
+		t.Fatalf("FindKShortestPaths returned an error: %v", err)
+	}
+	var got []string
+	for _, path := range paths {
+		got = append(got, path.String())
+	}
+	want := []string{"A -MemberOf-> B -AdminTo-> C -GenericAll-> D", "A -MemberOf-> E -GenericWrite-> C -HasSession-> F -DCSync-> D"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindKShortestPaths() = %q, want %q", got, want)
+	}
+	if len(queries) != 3 || !strings.Contains(queries[0], `none(r IN relationships(p) WHERE type(r) IN ["CanRDP"])`) {
+		t.Errorf("unexpected queries: %q", queries)
+	}
+
+	queries = nil
+	if paths, err := client.FindKShortestPaths(context.Background(), "A", "D", 1, 0, PathFilter{}); err != nil || len(paths) != 1 || len(queries) != 1 {
+		t.Errorf("FindKShortestPaths(k=1) = %d paths, %v after %d queries", len(paths), err, len(queries))
+	}
+	if _, err := client.FindKShortestPaths(context.Background(), "B", "D", 2, 0, PathFilter{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound without paths, got %v", err)
+	}
+}
+
+func TestPathsBetween(t *testing.T) {
+	nodes := map[string]GraphNodeProperties{
+		"1": {Name: "ALICE@CORP.LOCAL", Kind: "User", ObjectID: "S-1-5-21-1-1104"},
+		"2": {Name: "HELPDESK@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-1200"},
+		"3": {Name: "WS01.CORP.LOCAL", Kind: "Computer", ObjectID: "S-1-5-21-1-1000"},
+		"4": {Name: "DOMAIN ADMINS@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-512"},
+	}
+	edges := []GraphEdge{
+		{Source: "2", Target: "4", Kind: "GenericAll"},
+		{Source: "1", Target: "2", Kind: "MemberOf"},
+		{Source: "1", Target: "3", Kind: "AdminTo"},
+		{Source: "3", Target: "2", Kind: "MemberOf"},
+	}
+
+	paths := pathsBetween(nodes, edges, "s-1-5-21-1-1104", "S-1-5-21-1-512")
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(paths))
+	}
+	shortest := paths[0]
+	if shortest.Len() != 2 || shortest.Start().Name != "ALICE@CORP.LOCAL" || shortest.End().Name != "DOMAIN ADMINS@CORP.LOCAL" {
+		t.Errorf("unexpected shortest path: %+v", shortest)
+	}
+	if shortest.Edges[0].EdgeKind() != EdgeMemberOf || shortest.Edges[1].EdgeKind() != EdgeGenericAll {
+		t.Errorf("unexpected edge order: %+v", shortest.Edges)
+	}
+	if paths[1].Len() != 3 {
+		t.Errorf("expected the second path to have 3 hops, got %d", paths[1].Len())
+	}
+}
+
+func TestPathsBetweenDenseGraph(t *testing.T) {
+	// The first edges out of the start lead into layers that hold more than
+	// maxEnumeratedPaths long paths; the direct edge to the target comes last.
+	const width, layers = 4, 5
+	nodes := map[string]GraphNodeProperties{
+		"s": {Name: "ALICE@CORP.LOCAL", Kind: "User", ObjectID: "S-1-5-21-1-1104"},
+		"t": {Name: "DOMAIN ADMINS@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-512"},
+	}
+	var edges []GraphEdge
+	for layer := 0; layer < layers; layer++ {
+		for i := 0; i < width; i++ {
+			id := fmt.Sprintf("%d-%d", layer, i)
+			nodes[id] = GraphNodeProperties{Name: id, Kind: "Group", ObjectID: id}
+			if layer == 0 {
+				edges = append(edges, GraphEdge{Source: "s", Target: id, Kind: "MemberOf"})
+			}
+			if layer == layers-1 {
+				edges = append(edges, GraphEdge{Source: id, Target: "t", Kind: "GenericAll"})
+				continue
+			}
+			for j := 0; j < width; j++ {
+				edges = append(edges, GraphEdge{Source: id, Target: fmt.Sprintf("%d-%d", layer+1, j), Kind: "MemberOf"})
+			}
+		}
+	}
+	edges = append(edges, GraphEdge{Source: "s", Target: "t", Kind: "GenericAll"})
+
+	paths := pathsBetween(nodes, edges, "S-1-5-21-1-1104", "S-1-5-21-1-512")
+	if len(paths) != maxEnumeratedPaths {
+		t.Fatalf("expected %d paths, got %d", maxEnumeratedPaths, len(paths))
+	}
+	if paths[0].Len() != 1 || paths[0].Edges[0].EdgeKind() != EdgeGenericAll {
+		t.Errorf("expected the direct edge first, got a path of %d hops", paths[0].Len())
+	}
+	for i := 1; i < len(paths); i++ {
+		if paths[i].Len() < paths[i-1].Len() {
+			t.Fatalf("path %d has %d hops after a path of %d", i, paths[i].Len(), paths[i-1].Len())
+		}
+	}
+}
+
+func TestShortestPathDataReconstruction(t *testing.T) {
+	data := ShortestPathData{
+		Nodes: map[string]GraphNodeProperties{
+			"1": {Name: "ALICE@CORP.LOCAL", Kind: "User", ObjectID: "S-1-5-21-1-1104"},
+			"2": {Name: "HELPDESK@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-1200"},
+			"3": {Name: "IT@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-1201"},
+			"4": {Name: "DOMAIN ADMINS@CORP.LOCAL", Kind: "Group", ObjectID: "S-1-5-21-1-512"},
+		},
+		Edges: []GraphEdge{
+			{Source: "1", Target: "2", Kind: "MemberOf"},
+			{Source: "1", Target: "3", Kind: "MemberOf"},
+			{Source: "2", Target: "4", Kind: "GenericAll", Properties: map[string]interface{}{"isacl": true}},
+			{Source: "3", Target: "4", Kind: "AddMember"},
+		},
+	}
+
+	paths, err := data.Paths("", "")
+	if err != nil {
+		t.Fatalf("Paths() returned an error: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(paths))
+	}
+	if got := paths[0].String(); got != "ALICE@CORP.LOCAL -MemberOf-> HELPDESK@CORP.LOCAL -GenericAll-> DOMAIN ADMINS@CORP.LOCAL" &&
+		got != "ALICE@CORP.LOCAL -MemberOf-> IT@CORP.LOCAL -AddMember-> DOMAIN ADMINS@CORP.LOCAL" {
+		t.Errorf("unexpected chain: %s", got)
+	}
+
+	branches := data.Branches()
+	if len(branches) != 2 || branches[0].Node.ID != "1" || len(branches[0].Outgoing) != 2 || branches[1].Node.ID != "4" {
+		t.Errorf("unexpected branches: %+v", branches)
+	}
+	if _, err := data.Path("", ""); err == nil {
+		t.Error("expected Path() to fail on a branching graph")
+	}
+
+	for _, path := range paths {
+		for _, hop := range path.Hops() {
+			if hop.Kind == EdgeGenericAll && !hop.IsACL() {
+				t.Error("expected the GenericAll hop to be an ACL edge")
+			}
+		}
+	}
+}
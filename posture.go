@@ -0,0 +1,191 @@
+package bloodhound
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// PostureDataType selects the series returned by the posture history endpoint.
+type PostureDataType string
+
+const (
+	PostureFindings            PostureDataType = "findings"
+	PostureExposure            PostureDataType = "exposure"
+	PostureAssets              PostureDataType = "assets"
+	PostureSessionCompleteness PostureDataType = "session_completeness"
+	PostureGroupCompleteness   PostureDataType = "group_completeness"
+)
+
+// PostureStat is a point-in-time posture snapshot for a single domain or tenant.
+type PostureStat struct {
+	ID                int       `json:"id"`
+	DomainSID         string    `json:"domain_sid"`
+	ExposureIndex     float64   `json:"exposure_index"`
+	TierZeroCount     int       `json:"tier_zero_count"`
+	CriticalRiskCount int       `json:"critical_risk_count"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// PostureStatsResponse wraps a page of posture statistics.
+type PostureStatsResponse struct {
+	Count int           `json:"count"`
+	Limit int           `json:"limit"`
+	Skip  int           `json:"skip"`
+	Data  []PostureStat `json:"data"`
+}
+
+// PostureHistoryPoint is a single value of a posture time series.
+type PostureHistoryPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// PostureHistory is a posture time series, aggregated by the server across the requested environments.
+type PostureHistory struct {
+	DataType string                `json:"data_type"`
+	Measure  string                `json:"measure"`
+	Start    time.Time             `json:"start"`
+	End      time.Time             `json:"end"`
+	Points   []PostureHistoryPoint `json:"data"`
+}
+
+// FindingTrend describes how the count of a finding type changed over a time range.
+type FindingTrend struct {
+	EnvironmentID string  `json:"environment_id"`
+	Finding       string  `json:"finding"`
+	DisplayTitle  string  `json:"display_title"`
+	DisplayType   string  `json:"display_type"`
+	CompositeRisk float64 `json:"composite_risk"`
+	StartCount    int     `json:"finding_count_start"`
+	EndCount      int     `json:"finding_count_end"`
+	Increase      int     `json:"finding_count_increase"`
+	Decrease      int     `json:"finding_count_decrease"`
+}
+
+// FindingTrends holds the per-type finding trends over a time range.
+type FindingTrends struct {
+	Start           time.Time      `json:"start"`
+	End             time.Time      `json:"end"`
+	TotalStartCount int            `json:"total_finding_count_start"`
+	TotalEndCount   int            `json:"total_finding_count_end"`
+	Findings        []FindingTrend `json:"findings"`
+}
+
+// PostureOptions filters posture queries by environment and date range.
+// Zero values leave the corresponding filter unset.
+type PostureOptions struct {
+	Environments []string
+	Start        time.Time
+	End          time.Time
+	Skip         int
+	Limit        int
+}
+
+func (o PostureOptions) values(startKey, endKey string) url.Values {
+	params := url.Values{}
+	for _, environment := range o.Environments {
+		params.Add("environments", environment)
+	}
+	if !o.Start.IsZero() {
+		params.Add(startKey, o.Start.UTC().Format(time.RFC3339))
+	}
+	if !o.End.IsZero() {
+		params.Add(endKey, o.End.UTC().Format(time.RFC3339))
+	}
+	if o.Skip > 0 {
+		params.Add("skip", strconv.Itoa(o.Skip))
+	}
+	if o.Limit > 0 {
+		params.Add("limit", strconv.Itoa(o.Limit))
+	}
+	return params
+}
+
+// GetPostureStats fetches posture statistics for the requested date range.
+// Environments are matched against the domain SID or tenant ID of each statistic.
+func (c *Client) GetPostureStats(opts PostureOptions) (PostureStatsResponse, error) {
+	var rawResponse PostureStatsResponse
+	apiUrl := c.baseURL.JoinPath("/api/v2/posture-stats")
+	params := opts.values("from", "to")
+	params.Del("environments")
+	for _, environment := range opts.Environments {
+		params.Add("domain_sid", "eq:"+environment)
+	}
+	params.Add("sort_by", "created_at")
+	apiUrl.RawQuery = params.Encode()
+
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &rawResponse); err != nil {
+		return rawResponse, err
+	}
+	return rawResponse, nil
+}
+
+// GetPostureHistory fetches a posture time series for the requested environments and date range.
+func (c *Client) GetPostureHistory(dataType PostureDataType, opts PostureOptions) (*PostureHistory, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/posture-history/", string(dataType))
+	apiUrl.RawQuery = opts.values("start", "end").Encode()
+
+	var history PostureHistory
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// GetFindingTrends fetches the per-type finding counts at the start and end of the requested range.
+func (c *Client) GetFindingTrends(opts PostureOptions) (*FindingTrends, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/attack-paths/finding-trends")
+	apiUrl.RawQuery = opts.values("start", "end").Encode()
+
+	var response struct {
+		Data FindingTrends `json:"data"`
+	}
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// PostureSeriesPoint is a posture statistic aggregated across environments for one day.
+type PostureSeriesPoint struct {
+	Date              time.Time
+	Environments      int
+	CriticalRiskCount int
+	TierZeroCount     int
+	ExposureIndex     float64 // average across environments
+}
+
+// AggregatePostureStats merges per-environment posture statistics into a daily series,
+// summing counts and averaging exposure. When an environment has several statistics on
+// the same day, only the latest one is used.
+func AggregatePostureStats(stats []PostureStat) []PostureSeriesPoint {
+	latest := map[time.Time]map[string]PostureStat{}
+	for _, stat := range stats {
+		day := stat.CreatedAt.UTC().Truncate(24 * time.Hour)
+		if latest[day] == nil {
+			latest[day] = map[string]PostureStat{}
+		}
+		if existing, ok := latest[day][stat.DomainSID]; !ok || stat.CreatedAt.After(existing.CreatedAt) {
+			latest[day][stat.DomainSID] = stat
+		}
+	}
+
+	series := make([]PostureSeriesPoint, 0, len(latest))
+	for day, byEnvironment := range latest {
+		point := PostureSeriesPoint{Date: day, Environments: len(byEnvironment)}
+		for _, stat := range byEnvironment {
+			point.CriticalRiskCount += stat.CriticalRiskCount
+			point.TierZeroCount += stat.TierZeroCount
+			point.ExposureIndex += stat.ExposureIndex
+		}
+		point.ExposureIndex /= float64(point.Environments)
+		series = append(series, point)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Date.Before(series[j].Date) })
+	return series
+}
@@ -0,0 +1,29 @@
+package bloodhound
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAggregatePostureStats(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC) }
+	stats := []PostureStat{
+		{DomainSID: "S-1-5-21-2", CreatedAt: at(2, 8), CriticalRiskCount: 1, TierZeroCount: 10, ExposureIndex: 20},
+		{DomainSID: "S-1-5-21-1", CreatedAt: at(1, 8), CriticalRiskCount: 5, TierZeroCount: 40, ExposureIndex: 50},
+		// Superseded by the later statistic of the same domain on the same day.
+		{DomainSID: "S-1-5-21-1", CreatedAt: at(2, 6), CriticalRiskCount: 9, TierZeroCount: 99, ExposureIndex: 99},
+		{DomainSID: "S-1-5-21-1", CreatedAt: at(2, 18), CriticalRiskCount: 3, TierZeroCount: 42, ExposureIndex: 40},
+	}
+
+	want := []PostureSeriesPoint{
+		{Date: at(1, 0), Environments: 1, CriticalRiskCount: 5, TierZeroCount: 40, ExposureIndex: 50},
+		{Date: at(2, 0), Environments: 2, CriticalRiskCount: 4, TierZeroCount: 52, ExposureIndex: 30},
+	}
+	if got := AggregatePostureStats(stats); !reflect.DeepEqual(got, want) {
+		t.Errorf("AggregatePostureStats = %+v, want %+v", got, want)
+	}
+	if got := AggregatePostureStats(nil); len(got) != 0 {
+		t.Errorf("AggregatePostureStats(nil) = %+v, want an empty series", got)
+	}
+}
@@ -0,0 +1,415 @@
+package bloodhound
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownProperties caches, per struct type, the lower-cased JSON keys its fields decode.
+var knownProperties sync.Map // map[reflect.Type]map[string]bool
+
+// unmarshalWithExtra decodes data into v and stores every property that does not map to a
+// field of v in extra; marshalWithExtra writes them back out. v must be a pointer to a struct type without its own UnmarshalJSON
+// method, typically a local alias of the entity type being decoded.
+func unmarshalWithExtra(data []byte, v interface{}, extra *map[string]any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	*extra = nil
+	for key, value := range all {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if *extra == nil {
+			*extra = map[string]any{}
+		}
+		(*extra)[key] = value
+	}
+	return nil
+}
+
+// marshalWithExtra encodes v, typically a local alias of the entity type being encoded,
+// and adds the properties in extra that do not collide with a field of v.
+func marshalWithExtra(v interface{}, extra map[string]any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	known := jsonFieldNames(reflect.TypeOf(v))
+	for key, value := range extra {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		all[key] = raw
+	}
+	return json.Marshal(all)
+}
+
+// jsonFieldNames returns the lower-cased JSON keys decoded by the fields of struct type t,
+// including the fields promoted from embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if cached, ok := knownProperties.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	knownProperties.Store(t, names)
+	return names
+}
+
+func (u *ADUser) UnmarshalJSON(data []byte) error {
+	type plain ADUser
+	return unmarshalWithExtra(data, (*plain)(u), &u.Extra)
+}
+
+func (u ADUser) MarshalJSON() ([]byte, error) {
+	type plain ADUser
+	return marshalWithExtra(plain(u), u.Extra)
+}
+
+func (c *Computer) UnmarshalJSON(data []byte) error {
+	type plain Computer
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (c Computer) MarshalJSON() ([]byte, error) {
+	type plain Computer
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (g *Group) UnmarshalJSON(data []byte) error {
+	type plain Group
+	return unmarshalWithExtra(data, (*plain)(g), &g.Extra)
+}
+
+func (g Group) MarshalJSON() ([]byte, error) {
+	type plain Group
+	return marshalWithExtra(plain(g), g.Extra)
+}
+
+func (d *Domain) UnmarshalJSON(data []byte) error {
+	type plain Domain
+	return unmarshalWithExtra(data, (*plain)(d), &d.Extra)
+}
+
+func (d Domain) MarshalJSON() ([]byte, error) {
+	type plain Domain
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+func (g *GPO) UnmarshalJSON(data []byte) error {
+	type plain GPO
+	return unmarshalWithExtra(data, (*plain)(g), &g.Extra)
+}
+
+func (g GPO) MarshalJSON() ([]byte, error) {
+	type plain GPO
+	return marshalWithExtra(plain(g), g.Extra)
+}
+
+func (o *OU) UnmarshalJSON(data []byte) error {
+	type plain OU
+	return unmarshalWithExtra(data, (*plain)(o), &o.Extra)
+}
+
+func (o OU) MarshalJSON() ([]byte, error) {
+	type plain OU
+	return marshalWithExtra(plain(o), o.Extra)
+}
+
+func (c *Container) UnmarshalJSON(data []byte) error {
+	type plain Container
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+func (c Container) MarshalJSON() ([]byte, error) {
+	type plain Container
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (ca *AIACA) UnmarshalJSON(data []byte) error {
+	type plain AIACA
+	return unmarshalWithExtra(data, (*plain)(ca), &ca.Extra)
+}
+
+func (ca AIACA) MarshalJSON() ([]byte, error) {
+	type plain AIACA
+	return marshalWithExtra(plain(ca), ca.Extra)
+}
+
+func (ca *RootCA) UnmarshalJSON(data []byte) error {
+	type plain RootCA
+	return unmarshalWithExtra(data, (*plain)(ca), &ca.Extra)
+}
+
+func (ca RootCA) MarshalJSON() ([]byte, error) {
+	type plain RootCA
+	return marshalWithExtra(plain(ca), ca.Extra)
+}
+
+func (ca *EnterpriseCA) UnmarshalJSON(data []byte) error {
+	type plain EnterpriseCA
+	return unmarshalWithExtra(data, (*plain)(ca), &ca.Extra)
+}
+
+func (ca EnterpriseCA) MarshalJSON() ([]byte, error) {
+	type plain EnterpriseCA
+	return marshalWithExtra(plain(ca), ca.Extra)
+}
+
+func (s *NTAuthStore) UnmarshalJSON(data []byte) error {
+	type plain NTAuthStore
+	return unmarshalWithExtra(data, (*plain)(s), &s.Extra)
+}
+
+func (s NTAuthStore) MarshalJSON() ([]byte, error) {
+	type plain NTAuthStore
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (t *CertTemplate) UnmarshalJSON(data []byte) error {
+	type plain CertTemplate
+	return unmarshalWithExtra(data, (*plain)(t), &t.Extra)
+}
+
+func (t CertTemplate) MarshalJSON() ([]byte, error) {
+	type plain CertTemplate
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+func (p *IssuancePolicy) UnmarshalJSON(data []byte) error {
+	type plain IssuancePolicy
+	return unmarshalWithExtra(data, (*plain)(p), &p.Extra)
+}
+
+func (p IssuancePolicy) MarshalJSON() ([]byte, error) {
+	type plain IssuancePolicy
+	return marshalWithExtra(plain(p), p.Extra)
+}
+
+func (u *AzureUser) UnmarshalJSON(data []byte) error {
+	type plain AzureUser
+	return unmarshalWithExtra(data, (*plain)(u), &u.Extra)
+}
+
+func (u AzureUser) MarshalJSON() ([]byte, error) {
+	type plain AzureUser
+	return marshalWithExtra(plain(u), u.Extra)
+}
+
+func (g *AzureGroup) UnmarshalJSON(data []byte) error {
+	type plain AzureGroup
+	return unmarshalWithExtra(data, (*plain)(g), &g.Extra)
+}
+
+func (g AzureGroup) MarshalJSON() ([]byte, error) {
+	type plain AzureGroup
+	return marshalWithExtra(plain(g), g.Extra)
+}
+
+func (vm *AzureVM) UnmarshalJSON(data []byte) error {
+	type plain AzureVM
+	return unmarshalWithExtra(data, (*plain)(vm), &vm.Extra)
+}
+
+func (vm AzureVM) MarshalJSON() ([]byte, error) {
+	type plain AzureVM
+	return marshalWithExtra(plain(vm), vm.Extra)
+}
+
+func (t *AzureTenant) UnmarshalJSON(data []byte) error {
+	type plain AzureTenant
+	return unmarshalWithExtra(data, (*plain)(t), &t.Extra)
+}
+
+func (t AzureTenant) MarshalJSON() ([]byte, error) {
+	type plain AzureTenant
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+func (sp *AzureServicePrincipal) UnmarshalJSON(data []byte) error {
+	type plain AzureServicePrincipal
+	return unmarshalWithExtra(data, (*plain)(sp), &sp.Extra)
+}
+
+func (sp AzureServicePrincipal) MarshalJSON() ([]byte, error) {
+	type plain AzureServicePrincipal
+	return marshalWithExtra(plain(sp), sp.Extra)
+}
+
+func (a *AzureApp) UnmarshalJSON(data []byte) error {
+	type plain AzureApp
+	return unmarshalWithExtra(data, (*plain)(a), &a.Extra)
+}
+
+func (a AzureApp) MarshalJSON() ([]byte, error) {
+	type plain AzureApp
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (d *AzureDevice) UnmarshalJSON(data []byte) error {
+	type plain AzureDevice
+	return unmarshalWithExtra(data, (*plain)(d), &d.Extra)
+}
+
+func (d AzureDevice) MarshalJSON() ([]byte, error) {
+	type plain AzureDevice
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+func (r *AzureRole) UnmarshalJSON(data []byte) error {
+	type plain AzureRole
+	return unmarshalWithExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r AzureRole) MarshalJSON() ([]byte, error) {
+	type plain AzureRole
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (mg *AzureManagementGroup) UnmarshalJSON(data []byte) error {
+	type plain AzureManagementGroup
+	return unmarshalWithExtra(data, (*plain)(mg), &mg.Extra)
+}
+
+func (mg AzureManagementGroup) MarshalJSON() ([]byte, error) {
+	type plain AzureManagementGroup
+	return marshalWithExtra(plain(mg), mg.Extra)
+}
+
+func (s *AzureSubscription) UnmarshalJSON(data []byte) error {
+	type plain AzureSubscription
+	return unmarshalWithExtra(data, (*plain)(s), &s.Extra)
+}
+
+func (s AzureSubscription) MarshalJSON() ([]byte, error) {
+	type plain AzureSubscription
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (rg *AzureResourceGroup) UnmarshalJSON(data []byte) error {
+	type plain AzureResourceGroup
+	return unmarshalWithExtra(data, (*plain)(rg), &rg.Extra)
+}
+
+func (rg AzureResourceGroup) MarshalJSON() ([]byte, error) {
+	type plain AzureResourceGroup
+	return marshalWithExtra(plain(rg), rg.Extra)
+}
+
+func (kv *AzureKeyVault) UnmarshalJSON(data []byte) error {
+	type plain AzureKeyVault
+	return unmarshalWithExtra(data, (*plain)(kv), &kv.Extra)
+}
+
+func (kv AzureKeyVault) MarshalJSON() ([]byte, error) {
+	type plain AzureKeyVault
+	return marshalWithExtra(plain(kv), kv.Extra)
+}
+
+func (a *AzureAutomationAccount) UnmarshalJSON(data []byte) error {
+	type plain AzureAutomationAccount
+	return unmarshalWithExtra(data, (*plain)(a), &a.Extra)
+}
+
+func (a AzureAutomationAccount) MarshalJSON() ([]byte, error) {
+	type plain AzureAutomationAccount
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (r *AzureContainerRegistry) UnmarshalJSON(data []byte) error {
+	type plain AzureContainerRegistry
+	return unmarshalWithExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r AzureContainerRegistry) MarshalJSON() ([]byte, error) {
+	type plain AzureContainerRegistry
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (a *AzureFunctionApp) UnmarshalJSON(data []byte) error {
+	type plain AzureFunctionApp
+	return unmarshalWithExtra(data, (*plain)(a), &a.Extra)
+}
+
+func (a AzureFunctionApp) MarshalJSON() ([]byte, error) {
+	type plain AzureFunctionApp
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (a *AzureLogicApp) UnmarshalJSON(data []byte) error {
+	type plain AzureLogicApp
+	return unmarshalWithExtra(data, (*plain)(a), &a.Extra)
+}
+
+func (a AzureLogicApp) MarshalJSON() ([]byte, error) {
+	type plain AzureLogicApp
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (a *AzureWebApp) UnmarshalJSON(data []byte) error {
+	type plain AzureWebApp
+	return unmarshalWithExtra(data, (*plain)(a), &a.Extra)
+}
+
+func (a AzureWebApp) MarshalJSON() ([]byte, error) {
+	type plain AzureWebApp
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (mc *AzureManagedCluster) UnmarshalJSON(data []byte) error {
+	type plain AzureManagedCluster
+	return unmarshalWithExtra(data, (*plain)(mc), &mc.Extra)
+}
+
+func (mc AzureManagedCluster) MarshalJSON() ([]byte, error) {
+	type plain AzureManagedCluster
+	return marshalWithExtra(plain(mc), mc.Extra)
+}
+
+func (ss *AzureVMScaleSet) UnmarshalJSON(data []byte) error {
+	type plain AzureVMScaleSet
+	return unmarshalWithExtra(data, (*plain)(ss), &ss.Extra)
+}
+
+func (ss AzureVMScaleSet) MarshalJSON() ([]byte, error) {
+	type plain AzureVMScaleSet
+	return marshalWithExtra(plain(ss), ss.Extra)
+}
@@ -0,0 +1,372 @@
+package bloodhound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TargetClass is a category of high-value target for reachability analysis.
+type TargetClass string
+
+// High-value target classes, in order of priority.
+const (
+	TargetTierZero         TargetClass = "tier_zero"
+	TargetDomainAdmins     TargetClass = "domain_admins"
+	TargetDomainController TargetClass = "domain_controller"
+	TargetADCS             TargetClass = "adcs"
+)
+
+// DefaultReachabilityConcurrency is the number of targets analysed at once when none is set.
+const DefaultReachabilityConcurrency = 8
+
+// reachabilityOwnedBatch bounds the number of owned principals inlined in a single Cypher query.
+const reachabilityOwnedBatch = 500
+
+// DefaultTargetClasses are the target classes used when ReachabilityOptions.Targets is empty.
+var DefaultTargetClasses = []TargetClass{TargetTierZero, TargetDomainAdmins, TargetDomainController, TargetADCS}
+
+// targetClassQueries finds the candidates of the target classes that are not read from
+// an asset group.
+var targetClassQueries = map[TargetClass]string{
+	TargetDomainAdmins:     `MATCH (n:Group) WHERE n.objectid ENDS WITH "-512" RETURN n`,
+	TargetDomainController: `MATCH (n:Computer) WHERE n.isdc = true RETURN n`,
+	TargetADCS:             `MATCH (n) WHERE n:EnterpriseCA OR n:RootCA OR n:NTAuthStore RETURN n`,
+}
+
+func (t TargetClass) priority() int {
+	for i, class := range DefaultTargetClasses {
+		if class == t {
+			return i
+		}
+	}
+	return len(DefaultTargetClasses)
+}
+
+// ReachabilityOptions tunes ReachableFromOwned.
+type ReachabilityOptions struct {
+	// Targets restricts the target classes. Empty uses DefaultTargetClasses.
+	Targets []TargetClass
+	// Filter restricts the traversed edge kinds. The zero value uses TraversableFilter().
+	Filter PathFilter
+	// MaxDepth bounds the path length. Zero uses DefaultMaxPathDepth.
+	MaxDepth int
+	// Concurrency bounds the number of targets analysed at once. Zero uses DefaultReachabilityConcurrency.
+	Concurrency int
+}
+
+// ReachableTarget is a high-value target reachable from the owned principals.
+type ReachableTarget struct {
+	// Rank orders the targets, starting at 1: fewest hops first, then by target class.
+	Rank     int
+	ObjectID string
+	Name     string
+	Kind     string
+	Classes  []TargetClass
+	// Paths holds the shortest path from each owned principal that reaches the target,
+	// shortest first.
+	Paths []Path
+}
+
+// Path returns the shortest path to the target.
+func (t ReachableTarget) Path() Path { return t.Paths[0] }
+
+// Hops returns the length of the shortest path to the target.
+func (t ReachableTarget) Hops() int { return t.Paths[0].Len() }
+
+// EdgeKinds returns the edge kinds along the shortest path to the target.
+func (t ReachableTarget) EdgeKinds() []EdgeKind { return t.Paths[0].EdgeKinds() }
+
+// ReachabilityResult holds the targets reachable from the owned principals, ranked,
+// along with the error for every target or target class that could not be analysed.
+type ReachabilityResult struct {
+	Owned   []AssetGroupMember
+	Targets []ReachableTarget
+	Errors  map[string]error
+}
+
+// Err joins the errors, or returns nil when the analysis was complete.
+func (r *ReachabilityResult) Err() error {
+	keys := make([]string, 0, len(r.Errors))
+	for key := range r.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	errs := make([]error, 0, len(keys))
+	for _, key := range keys {
+		errs = append(errs, fmt.Errorf("%s: %w", key, r.Errors[key]))
+	}
+	return errors.Join(errs...)
+}
+
+// reachabilityTarget is a candidate target before its paths are known.
+type reachabilityTarget struct {
+	objectID string
+	name     string
+	kind     string
+	classes  []TargetClass
+}
+
+// ReachableFromOwned answers "what can I reach now": it reads the members of the Owned
+// asset group, collects the high-value targets and finds the shortest paths from the
+// owned principals to each of them. Paths are found with one Cypher query per target,
+// falling back to GetShortestPath for every owned principal when Cypher is unavailable.
+// Owned targets and unreachable targets are left out. When ctx is done before the
+// analysis completes, ctx.Err() is returned.
+func (c *Client) ReachableFromOwned(ctx context.Context, opts ReachabilityOptions) (*ReachabilityResult, error) {
+	ownedGroup, err := c.getAssetGroupByTag(ctx, TagOwned)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := c.listAllAssetGroupMembers(ctx, ownedGroup.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReachabilityResult{Owned: owned, Errors: map[string]error{}}
+	if len(owned) == 0 {
+		return result, nil
+	}
+
+	targets, err := c.reachabilityTargets(ctx, opts.Targets, result.Errors)
+	if err != nil {
+		return nil, err
+	}
+	ownedIDs := make([]string, 0, len(owned))
+	isOwned := map[string]bool{}
+	for _, member := range owned {
+		key := strings.ToUpper(member.ObjectID)
+		if !isOwned[key] {
+			isOwned[key] = true
+			ownedIDs = append(ownedIDs, key)
+		}
+	}
+	var pending []reachabilityTarget
+	for _, target := range targets {
+		if !isOwned[target.objectID] {
+			pending = append(pending, target)
+		}
+	}
+
+	filter := opts.Filter
+	if filter.IsZero() {
+		filter = TraversableFilter()
+	}
+	maxDepth := opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxPathDepth
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultReachabilityConcurrency
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan reachabilityTarget)
+	)
+	for i := 0; i < concurrency && i < len(pending); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range jobs {
+				paths, err := c.pathsFromOwned(ctx, ownedIDs, target.objectID, filter, maxDepth)
+				mu.Lock()
+				if err != nil {
+					result.Errors[target.objectID] = err
+				} else if len(paths) > 0 {
+					result.Targets = append(result.Targets, ReachableTarget{
+						ObjectID: target.objectID,
+						Name:     target.name,
+						Kind:     target.kind,
+						Classes:  target.classes,
+						Paths:    paths,
+					})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, target := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- target
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rankReachableTargets(result.Targets)
+	return result, nil
+}
+
+// reachabilityTargets collects the candidates of the target classes, keyed by upper-case
+// Object ID. Classes that cannot be read are recorded in errs.
+func (c *Client) reachabilityTargets(ctx context.Context, classes []TargetClass, errs map[string]error) ([]reachabilityTarget, error) {
+	if len(classes) == 0 {
+		classes = DefaultTargetClasses
+	}
+
+	byID := map[string]*reachabilityTarget{}
+	var order []string
+	add := func(class TargetClass, objectID, name, kind string) {
+		key := strings.ToUpper(objectID)
+		if key == "" {
+			return
+		}
+		target, ok := byID[key]
+		if !ok {
+			target = &reachabilityTarget{objectID: key, name: name, kind: kind}
+			byID[key] = target
+			order = append(order, key)
+		}
+		for _, existing := range target.classes {
+			if existing == class {
+				return
+			}
+		}
+		target.classes = append(target.classes, class)
+	}
+
+	for _, class := range classes {
+		if class == TargetTierZero {
+			inventory, err := c.listTierZeroMembers(ctx)
+			if err != nil {
+				errs[string(class)] = err
+				continue
+			}
+			for _, member := range inventory.Members {
+				add(class, member.ObjectID, member.Name, member.PrimaryKind)
+			}
+			continue
+		}
+
+		query, ok := targetClassQueries[class]
+		if !ok {
+			return nil, fmt.Errorf("unknown target class: %s", class)
+		}
+		graph, err := c.RunCypherGraph(ctx, query)
+		if err != nil {
+			errs[string(class)] = err
+			continue
+		}
+		for _, node := range graph.Nodes {
+			add(class, objectIDOf(node), node.Name, node.Kind)
+		}
+	}
+
+	targets := make([]reachabilityTarget, 0, len(order))
+	for _, key := range order {
+		targets = append(targets, *byID[key])
+	}
+	return targets, nil
+}
+
+// pathsFromOwned returns the shortest path from each owned principal that reaches the
+// target, shortest first. Owned principals without a path to the target are skipped.
+func (c *Client) pathsFromOwned(ctx context.Context, ownedIDs []string, targetID string, filter PathFilter, maxDepth int) ([]Path, error) {
+	paths, err := c.cypherPathsFromOwned(ctx, ownedIDs, targetID, filter, maxDepth)
+	if err == nil {
+		return paths, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	paths = nil
+	for _, ownedID := range ownedIDs {
+		path, err := c.FindShortestPath(ctx, ownedID, targetID, filter)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Len() <= maxDepth {
+			paths = append(paths, *path)
+		}
+	}
+	sortPathsByLength(paths)
+	return paths, nil
+}
+
+// cypherPathsFromOwned finds the shortest paths from the owned principals to the target
+// with Cypher, in batches of owned principals.
+func (c *Client) cypherPathsFromOwned(ctx context.Context, ownedIDs []string, targetID string, filter PathFilter, maxDepth int) ([]Path, error) {
+	g := NewGraph()
+	for start := 0; start < len(ownedIDs); start += reachabilityOwnedBatch {
+		end := min(start+reachabilityOwnedBatch, len(ownedIDs))
+		quoted := make([]string, 0, end-start)
+		for _, id := range ownedIDs[start:end] {
+			quoted = append(quoted, cypherString(id))
+		}
+		query := fmt.Sprintf("MATCH p = shortestPath((s)-[%s*1..%d]->(t)) WHERE s.objectid IN [%s] AND t.objectid = %s%s RETURN p",
+			filter.cypherPattern(), maxDepth, strings.Join(quoted, ", "), cypherString(targetID), filter.cypherPredicate("p"))
+		data, err := c.RunCypherGraph(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		g.AddGraph(data.Nodes, data.Edges)
+	}
+	return g.shortestPathsTo(ownedIDs, targetID), nil
+}
+
+// shortestPathsTo returns the shortest path to the target from each start Object ID that
+// reaches it, shortest first.
+func (g *Graph) shortestPathsTo(startObjectIDs []string, targetObjectID string) []Path {
+	targetID, ok := g.NodeIDForObject(targetObjectID)
+	if !ok {
+		return nil
+	}
+	var paths []Path
+	for _, objectID := range startObjectIDs {
+		startID, ok := g.NodeIDForObject(objectID)
+		if !ok || startID == targetID {
+			continue
+		}
+		if path, _, ok := g.CheapestPath(startID, targetID, UnitCost); ok {
+			paths = append(paths, path)
+		}
+	}
+	sortPathsByLength(paths)
+	return paths
+}
+
+func sortPathsByLength(paths []Path) {
+	sort.SliceStable(paths, func(i, j int) bool { return paths[i].Len() < paths[j].Len() })
+}
+
+// rankReachableTargets orders the targets by hops, then by their highest-priority class,
+// then by name, and numbers them.
+func rankReachableTargets(targets []ReachableTarget) {
+	classPriority := func(t ReachableTarget) int {
+		best := len(DefaultTargetClasses)
+		for _, class := range t.Classes {
+			best = min(best, class.priority())
+		}
+		return best
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.Hops() != b.Hops() {
+			return a.Hops() < b.Hops()
+		}
+		if pa, pb := classPriority(a), classPriority(b); pa != pb {
+			return pa < pb
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ObjectID < b.ObjectID
+	})
+	for i := range targets {
+		targets[i].Rank = i + 1
+	}
+}
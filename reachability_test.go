@@ -0,0 +1,104 @@
+package bloodhound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestShortestPathsToAndRanking(t *testing.T) {
+	g := testGraph()
+	paths := g.shortestPathsTo([]string{"S-1-5-21-1-1104", "s-1-5-21-1-1200", "S-1-5-21-1-9999"}, "S-1-5-21-1-512")
+	if len(paths) != 2 {
+		t.Fatalf("got %d paths, want 2", len(paths))
+	}
+	if paths[0].Len() != 2 || paths[0].Start().ID != "u" || paths[1].Len() != 2 || paths[1].Start().ID != "a" {
+		t.Errorf("unexpected paths: %v, %v", paths[0], paths[1])
+	}
+
+	targets := []ReachableTarget{
+		{Name: "CORP-CA@CORP.LOCAL", Classes: []TargetClass{TargetADCS}, Paths: []Path{{Edges: make([]GraphEdge, 2)}}},
+		{Name: "DC01.CORP.LOCAL", Classes: []TargetClass{TargetDomainController}, Paths: []Path{{Edges: make([]GraphEdge, 3)}}},
+		{Name: "DOMAIN ADMINS@CORP.LOCAL", Classes: []TargetClass{TargetDomainAdmins, TargetTierZero}, Paths: []Path{{Edges: make([]GraphEdge, 2)}}},
+	}
+	rankReachableTargets(targets)
+	if targets[0].Name != "DOMAIN ADMINS@CORP.LOCAL" || targets[0].Rank != 1 || targets[2].Name != "DC01.CORP.LOCAL" || targets[2].Hops() != 3 {
+		t.Errorf("unexpected ranking: %+v", targets)
+	}
+}
+
+func TestReachableFromOwned(t *testing.T) {
+	var shortestPathCalls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/asset-groups", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, `{"data": {"asset_groups": [
+			{"id": 1, "name": "Admin Tier Zero", "tag": "admin_tier_0"},
+			{"id": 2, "name": "Owned", "tag": "owned"}]}}`)
+	})
+	mux.HandleFunc("/api/v2/asset-groups/1/members", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, `{"count": 2, "data": {"members": [
+			{"object_id": "S-1-5-21-1-512", "name": "DOMAIN ADMINS@CORP.LOCAL", "primary_kind": "Group"},
+			{"object_id": "S-1-5-21-1-1001", "name": "DC01.CORP.LOCAL", "primary_kind": "Computer"}]}}`)
+	})
+	mux.HandleFunc("/api/v2/asset-groups/2/members", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, `{"count": 1, "data": {"members": [
+			{"object_id": "S-1-5-21-1-1104", "name": "ALICE@CORP.LOCAL", "primary_kind": "User"}]}}`)
+	})
+	mux.HandleFunc("/api/v2/graphs/cypher", cypherHandler(t, map[string]string{
+		`t.objectid = "S-1-5-21-1-512"`: `{
+			"nodes": {
+				"1": {"label": "ALICE@CORP.LOCAL", "kind": "User", "objectId": "S-1-5-21-1-1104"},
+				"2": {"label": "HELPDESK@CORP.LOCAL", "kind": "Group", "objectId": "S-1-5-21-1-1200"},
+				"3": {"label": "DOMAIN ADMINS@CORP.LOCAL", "kind": "Group", "objectId": "S-1-5-21-1-512"}},
+			"edges": [
+				{"source": "1", "target": "2", "kind": "MemberOf"},
+				{"source": "2", "target": "3", "kind": "GenericAll"}]}`,
+	}))
+	mux.HandleFunc("/api/v2/graphs/shortest-path", func(w http.ResponseWriter, r *http.Request) {
+		shortestPathCalls++
+		writeTestJSON(w, http.StatusNotFound, `{"errors": [{"message": "Path not found"}]}`)
+	})
+	client := newTestClient(t, mux)
+
+	result, err := client.ReachableFromOwned(context.Background(), ReachabilityOptions{Targets: []TargetClass{TargetTierZero}, Concurrency: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Errorf("unreachable target reported as an error: %v", err)
+	}
+	if shortestPathCalls != 0 {
+		t.Errorf("fell back to the shortest path endpoint %d times", shortestPathCalls)
+	}
+	if len(result.Targets) != 1 {
+		t.Fatalf("got %d reachable targets, want 1", len(result.Targets))
+	}
+	target := result.Targets[0]
+	if target.Name != "DOMAIN ADMINS@CORP.LOCAL" || target.Hops() != 2 || target.EdgeKinds()[1] != EdgeGenericAll {
+		t.Errorf("unexpected target: %+v", target)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.FindShortestPath(ctx, "A", "B", PathFilter{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindShortestPath() with a cancelled context = %v", err)
+	}
+	if result, err := client.ReachableFromOwned(ctx, ReachabilityOptions{}); result != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("ReachableFromOwned() with a cancelled context = %v, %v", result, err)
+	}
+
+	// The context is cancelled while the paths are being searched.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	cancelling := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/graphs/cypher" {
+			cancel()
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	opts := ReachabilityOptions{Targets: []TargetClass{TargetTierZero}, Concurrency: 1}
+	if result, err := cancelling.ReachableFromOwned(ctx, opts); result != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("ReachableFromOwned() cancelled during the analysis = %v, %v", result, err)
+	}
+}
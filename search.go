@@ -1,44 +1,229 @@
 package bloodhound
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Search looks for an object in BloodHound by its name, optionally filtering by type and limit.
 func (c *Client) Search(searchTerm, objectType string, limit int) (*SearchResponse, error) {
+	opts := SearchOptions{Limit: limit}
+	if objectType != "" {
+		opts.Kinds = []string{objectType}
+	}
+	return c.SearchWithOptions(searchTerm, opts)
+}
+
+// SearchOptions filters and pages a search. Leaving Kinds empty searches every kind.
+type SearchOptions struct {
+	Kinds []string
+	Skip  int
+	Limit int
+}
+
+// SearchWithOptions looks for objects by name across one or more kinds.
+func (c *Client) SearchWithOptions(searchTerm string, opts SearchOptions) (*SearchResponse, error) {
 	apiUrl := c.baseURL.JoinPath("/api/v2/search")
 	params := url.Values{}
 	params.Add("q", searchTerm)
-	if objectType != "" {
-		params.Add("type", objectType)
+	for _, kind := range opts.Kinds {
+		params.Add("type", kind)
+	}
+	if opts.Skip > 0 {
+		params.Add("skip", strconv.Itoa(opts.Skip))
 	}
-	if limit > 0 {
-		params.Add("limit", fmt.Sprintf("%d", limit))
+	if opts.Limit > 0 {
+		params.Add("limit", strconv.Itoa(opts.Limit))
 	}
 	apiUrl.RawQuery = params.Encode()
 
-	req, err := c.newAuthenticatedRequest(http.MethodGet, apiUrl.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create search request: %w", err)
+	var searchResponse SearchResponse
+	if err := c.doJSON(context.Background(), http.MethodGet, apiUrl, nil, &searchResponse); err != nil {
+		return nil, err
+	}
+	return &searchResponse, nil
+}
+
+// SearchAll pages through every search result. opts.Skip is ignored and opts.Limit
+// sets the page size.
+func (c *Client) SearchAll(searchTerm string, opts SearchOptions) ([]SearchResult, error) {
+	pageSize := opts.Limit
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return listAll(pageSize, func(skip, limit int) ([]SearchResult, int, error) {
+		page, err := c.SearchWithOptions(searchTerm, SearchOptions{Kinds: opts.Kinds, Skip: skip, Limit: limit})
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Data, page.Count, nil
+	})
+}
+
+// ErrNotFound is wrapped by the errors returned when a name resolves to no object or
+// no path exists between two objects.
+var ErrNotFound = errors.New("not found")
+
+// AmbiguousMatchError is returned when a name resolves equally well to several objects.
+type AmbiguousMatchError struct {
+	Name       string
+	Candidates []SearchResult
+}
+
+func (e *AmbiguousMatchError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, candidate := range e.Candidates {
+		candidates = append(candidates, fmt.Sprintf("%s (%s, %s)", candidate.Name, candidate.ObjectType, candidate.ObjectID))
+	}
+	return fmt.Sprintf("%q matches %d objects, use a fully qualified name or the object ID: %s",
+		e.Name, len(e.Candidates), strings.Join(candidates, ", "))
+}
+
+// RankedCandidate is a search result scored against the name being resolved.
+type RankedCandidate struct {
+	SearchResult
+	Score int
+}
+
+// Match scores used by RankCandidates, from best to worst.
+const (
+	MatchExact         = 100 // the name is exactly the BloodHound name, e.g. JDOE@CORP.LOCAL
+	MatchNameAndDomain = 90  // account name and domain match, e.g. CORP\jdoe
+	MatchName          = 70  // account name matches and no domain was given, e.g. jdoe
+	MatchNameOnly      = 40  // account name matches but the domain does not, e.g. a UPN suffix
+)
+
+// nameParts splits a name into an account name and an optional domain. It understands
+// NAME@DOMAIN (also UPNs), DOMAIN\sam, host.domain.fqdn computer names and bare names;
+// a trailing $ on computer accounts is ignored.
+func nameParts(name string, splitDots bool) (account, domain string) {
+	name = strings.TrimSpace(name)
+	switch {
+	case strings.Contains(name, `\`):
+		domain, account, _ = strings.Cut(name, `\`)
+	case strings.Contains(name, "@"):
+		at := strings.LastIndex(name, "@")
+		account, domain = name[:at], name[at+1:]
+	case splitDots && strings.Contains(name, "."):
+		account, domain, _ = strings.Cut(name, ".")
+	default:
+		account = name
+	}
+	return strings.TrimSuffix(account, "$"), domain
+}
+
+// domainMatches reports whether a domain given by the caller (FQDN or NetBIOS name)
+// matches the FQDN of a candidate.
+func domainMatches(given, candidate string) bool {
+	return strings.EqualFold(given, candidate) ||
+		(len(candidate) > len(given) && strings.EqualFold(candidate[:len(given)+1], given+"."))
+}
+
+// RankCandidates scores search results against a name and returns the matching ones,
+// best first. Results that do not match the account name at all are dropped.
+func RankCandidates(name string, results []SearchResult) []RankedCandidate {
+	account, domain := nameParts(name, false)
+
+	var ranked []RankedCandidate
+	for _, result := range results {
+		score := 0
+		candidateAccount, candidateDomain := nameParts(result.Name, true)
+		switch {
+		case strings.EqualFold(result.Name, strings.TrimSpace(name)):
+			score = MatchExact
+		case !strings.EqualFold(candidateAccount, account):
+		case domain == "":
+			score = MatchName
+		case domainMatches(domain, candidateDomain):
+			score = MatchNameAndDomain
+		default:
+			score = MatchNameOnly
+		}
+		if score > 0 {
+			ranked = append(ranked, RankedCandidate{SearchResult: result, Score: score})
+		}
 	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	return ranked
+}
 
-	resp, err := c.do(req, nil)
+// ResolveName resolves a name to exactly one object of the given kinds (any kind when none
+// are given). Names may be NAME@DOMAIN, DOMAIN\sam, a bare SAM account name or a UPN.
+// When several objects match equally well, an *AmbiguousMatchError lists them; when none
+// match, the error wraps ErrNotFound.
+func (c *Client) ResolveName(name string, kinds ...string) (SearchResult, error) {
+	result, _, err := c.resolveName(name, kinds)
+	return result, err
+}
+
+// resolveName resolves a name like ResolveName and also returns the search results it
+// ranked, without duplicates.
+func (c *Client) resolveName(name string, kinds []string) (SearchResult, []SearchResult, error) {
+	account, _ := nameParts(name, false)
+	results, err := c.SearchAll(account, SearchOptions{Kinds: kinds})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute search request: %w", err)
+		return SearchResult{}, nil, err
+	}
+	if !strings.EqualFold(account, strings.TrimSpace(name)) {
+		// The server may also match the full name, e.g. a computer's FQDN.
+		full, err := c.SearchAll(strings.TrimSpace(name), SearchOptions{Kinds: kinds})
+		if err != nil {
+			return SearchResult{}, nil, err
+		}
+		results = append(results, full...)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("search request failed with status code: %d", resp.StatusCode)
+	seen := map[string]bool{}
+	unique := results[:0]
+	for _, result := range results {
+		if !seen[result.ObjectID] {
+			seen[result.ObjectID] = true
+			unique = append(unique, result)
+		}
 	}
 
-	var searchResponse SearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	ranked := RankCandidates(name, unique)
+	if len(ranked) == 0 {
+		label := "object"
+		if len(kinds) > 0 {
+			label = strings.ToLower(strings.Join(kinds, "/"))
+		}
+		return SearchResult{}, unique, fmt.Errorf("%s %w: %s", label, ErrNotFound, name)
 	}
 
-	return &searchResponse, nil
+	best := ranked[0].Score
+	var top []SearchResult
+	for _, candidate := range ranked {
+		if candidate.Score == best {
+			top = append(top, candidate.SearchResult)
+		}
+	}
+	if len(top) > 1 {
+		return SearchResult{}, unique, &AmbiguousMatchError{Name: name, Candidates: top}
+	}
+	return top[0], unique, nil
+}
+
+// resolveObjectID resolves a name of the given kind to its Object ID. Like the Get*ByName
+// methods always have, it falls back to the only search result when none matches the name.
+func (c *Client) resolveObjectID(kind, name string) (string, error) {
+	result, results, err := c.resolveName(name, []string{kind})
+	if errors.Is(err, ErrNotFound) && len(results) == 1 {
+		return results[0].ObjectID, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return result.ObjectID, nil
 }
@@ -0,0 +1,84 @@
+package bloodhound
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRankCandidates(t *testing.T) {
+	results := []SearchResult{
+		{ObjectID: "S-1-5-21-1-1104", Name: "JDOE@CORP.LOCAL", ObjectType: "User"},
+		{ObjectID: "S-1-5-21-2-1104", Name: "JDOE@DEV.CORP.LOCAL", ObjectType: "User"},
+		{ObjectID: "S-1-5-21-1-1105", Name: "JDOE.ADMIN@CORP.LOCAL", ObjectType: "User"},
+		{ObjectID: "S-1-5-21-1-1000", Name: "WS01.CORP.LOCAL", ObjectType: "Computer"},
+	}
+
+	tests := []struct {
+		name      string
+		wantIDs   []string
+		wantScore int
+	}{
+		{"jdoe@corp.local", []string{"S-1-5-21-1-1104"}, MatchExact},
+		{`CORP\jdoe`, []string{"S-1-5-21-1-1104"}, MatchNameAndDomain},
+		{`DEV\jdoe`, []string{"S-1-5-21-2-1104"}, MatchNameAndDomain},
+		{"jdoe", []string{"S-1-5-21-1-1104", "S-1-5-21-2-1104"}, MatchName},
+		{"jdoe@corp.com", []string{"S-1-5-21-1-1104", "S-1-5-21-2-1104"}, MatchNameOnly},
+		{`CORP\WS01$`, []string{"S-1-5-21-1-1000"}, MatchNameAndDomain},
+		{"jdoe.admin", []string{"S-1-5-21-1-1105"}, MatchName},
+	}
+	for _, test := range tests {
+		ranked := RankCandidates(test.name, results)
+		var top []string
+		for _, candidate := range ranked {
+			if candidate.Score == ranked[0].Score {
+				top = append(top, candidate.ObjectID)
+			}
+		}
+		if len(ranked) == 0 || ranked[0].Score != test.wantScore || len(top) != len(test.wantIDs) {
+			t.Errorf("RankCandidates(%q) = %+v, want %v with score %d", test.name, ranked, test.wantIDs, test.wantScore)
+			continue
+		}
+		for i, id := range test.wantIDs {
+			if top[i] != id {
+				t.Errorf("RankCandidates(%q) best match %d = %s, want %s", test.name, i, top[i], id)
+			}
+		}
+	}
+
+	if ranked := RankCandidates("nobody", results); len(ranked) != 0 {
+		t.Errorf("expected no candidates for an unknown name, got %+v", ranked)
+	}
+}
+
+func TestResolveName(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/search", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "jdoe":
+			writeTestJSON(w, http.StatusOK, `{"data": [{"objectid": "S-1-5-21-1-1104", "name": "JDOE@CORP.LOCAL", "type": "User"}]}`)
+		case "legacy":
+			writeTestJSON(w, http.StatusOK, `{"data": [{"objectid": "S-1-5-21-1-1105", "name": "LEGACY-SVC@CORP.LOCAL", "type": "User"}]}`)
+		default:
+			writeTestJSON(w, http.StatusInternalServerError, `{"errors": [{"message": "boom"}]}`)
+		}
+	})
+	client := newTestClient(t, mux)
+
+	if result, err := client.ResolveName("jdoe", "User"); err != nil || result.ObjectID != "S-1-5-21-1-1104" {
+		t.Errorf("ResolveName(jdoe) = %+v, %v", result, err)
+	}
+	// The full-name search fails, and that error is returned rather than dropped.
+	if _, err := client.ResolveName("jdoe@corp.local", "User"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the error of the full-name search, got %v", err)
+	}
+
+	// A lone search result that does not match the name is not a match for ResolveName,
+	// but the Get*ByName methods still fall back to it.
+	if _, err := client.ResolveName("legacy", "User"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a lone non-matching result, got %v", err)
+	}
+	if objectID, err := client.resolveObjectID("User", "legacy"); err != nil || objectID != "S-1-5-21-1-1105" {
+		t.Errorf("resolveObjectID(legacy) = %q, %v", objectID, err)
+	}
+}
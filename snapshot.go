@@ -0,0 +1,367 @@
+package bloodhound
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultSnapshotPageSize is the number of nodes fetched per Cypher query when taking a snapshot.
+const DefaultSnapshotPageSize = 1000
+
+// SnapshotVersion is the version of the snapshot format written by TakeSnapshot.
+const SnapshotVersion = 1
+
+// SnapshotOptions tunes TakeSnapshot.
+type SnapshotOptions struct {
+	// PageSize is the number of nodes fetched per query. Zero uses DefaultSnapshotPageSize.
+	PageSize int
+	// Progress, if set, is called after every page with the running node and edge counts.
+	Progress func(nodes, edges int)
+}
+
+// SnapshotHeader describes a snapshot. It is the first record of a snapshot file.
+type SnapshotHeader struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Server    string    `json:"server,omitempty"`
+	Nodes     int       `json:"-"`
+	Edges     int       `json:"-"`
+}
+
+// Snapshot is a point-in-time copy of a graph loaded back from a snapshot file.
+type Snapshot struct {
+	Header SnapshotHeader
+	Graph  *Graph
+}
+
+// snapshotRecord is a line of a snapshot file: the header, a node or an edge.
+type snapshotRecord struct {
+	Type   string               `json:"type"`
+	Header *SnapshotHeader      `json:"header,omitempty"`
+	ID     string               `json:"id,omitempty"`
+	Node   *GraphNodeProperties `json:"node,omitempty"`
+	Edge   *GraphEdge           `json:"edge,omitempty"`
+}
+
+// snapshotWriter writes the records of a snapshot as gzip-compressed JSON Lines.
+type snapshotWriter struct {
+	gz      *gzip.Writer
+	encoder *json.Encoder
+	header  SnapshotHeader
+}
+
+func newSnapshotWriter(w io.Writer, header SnapshotHeader) (*snapshotWriter, error) {
+	gz := gzip.NewWriter(w)
+	sw := &snapshotWriter{gz: gz, encoder: json.NewEncoder(gz), header: header}
+	if err := sw.encoder.Encode(snapshotRecord{Type: "header", Header: &header}); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	return sw, nil
+}
+
+func (sw *snapshotWriter) writeNode(id string, node GraphNodeProperties) error {
+	sw.header.Nodes++
+	if err := sw.encoder.Encode(snapshotRecord{Type: "node", ID: id, Node: &node}); err != nil {
+		return fmt.Errorf("failed to write snapshot node %s: %w", id, err)
+	}
+	return nil
+}
+
+func (sw *snapshotWriter) writeEdge(edge GraphEdge) error {
+	sw.header.Edges++
+	if err := sw.encoder.Encode(snapshotRecord{Type: "edge", Edge: &edge}); err != nil {
+		return fmt.Errorf("failed to write snapshot edge %s -> %s: %w", edge.Source, edge.Target, err)
+	}
+	return nil
+}
+
+func (sw *snapshotWriter) close() error {
+	return sw.gz.Close()
+}
+
+// TakeSnapshot writes every node and edge of the instance, with their properties, to w as
+// gzip-compressed JSON Lines. Nodes are fetched with Cypher in pages ordered by their internal
+// graph ID, and each page is followed by the edges leaving its nodes, paged the same way. Nodes
+// without an Object ID are not included. The returned header holds the node and edge counts.
+func (c *Client) TakeSnapshot(ctx context.Context, w io.Writer, opts SnapshotOptions) (*SnapshotHeader, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultSnapshotPageSize
+	}
+
+	sw, err := newSnapshotWriter(w, SnapshotHeader{Version: SnapshotVersion, CreatedAt: time.Now().UTC(), Server: c.baseURL.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	// The cursor is the largest internal ID of the previous page. Internal IDs are integers,
+	// so the server and the client agree on their order whatever the database collation.
+	var cursor int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		query := fmt.Sprintf("MATCH (n) WHERE id(n) > %d AND n.objectid IS NOT NULL RETURN n ORDER BY id(n) LIMIT %d", cursor, pageSize)
+		page, err := c.RunCypherGraph(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch snapshot nodes after %d: %w", cursor, err)
+		}
+		if len(page.Nodes) == 0 {
+			break
+		}
+
+		ids := make([]int64, 0, len(page.Nodes))
+		for key := range page.Nodes {
+			id, err := strconv.ParseInt(key, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected snapshot node ID %q: %w", key, err)
+			}
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			key := strconv.FormatInt(id, 10)
+			if node := page.Nodes[key]; objectIDOf(node) != "" {
+				if err := sw.writeNode(key, node); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		last := ids[len(ids)-1]
+		if err := c.snapshotEdges(ctx, sw, cursor, last, pageSize); err != nil {
+			return nil, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(sw.header.Nodes, sw.header.Edges)
+		}
+		if len(ids) < pageSize {
+			break
+		}
+		cursor = last
+	}
+
+	if err := sw.close(); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return &sw.header, nil
+}
+
+// snapshotEdges writes the edges leaving the nodes with internal IDs in (after, last], in
+// pages of pageSize ordered by relationship ID, so that a node with a large fan-out does not
+// pull all of its edges into a single response.
+func (c *Client) snapshotEdges(ctx context.Context, sw *snapshotWriter, after, last int64, pageSize int) error {
+	for skip := 0; ; skip += pageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		query := fmt.Sprintf("MATCH p = (s)-[r]->() WHERE id(s) > %d AND id(s) <= %d RETURN p ORDER BY id(r) SKIP %d LIMIT %d", after, last, skip, pageSize)
+		page, err := c.RunCypherGraph(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to fetch snapshot edges after %d: %w", after, err)
+		}
+		for _, edge := range page.Edges {
+			if err := sw.writeEdge(edge); err != nil {
+				return err
+			}
+		}
+		if len(page.Edges) < pageSize {
+			return nil
+		}
+	}
+}
+
+// WriteSnapshot writes an in-memory graph to w in the snapshot format, e.g. to save a
+// subgraph extracted from a loaded snapshot.
+func WriteSnapshot(w io.Writer, g *Graph, header SnapshotHeader) error {
+	if header.Version == 0 {
+		header.Version = SnapshotVersion
+	}
+	if header.CreatedAt.IsZero() {
+		header.CreatedAt = time.Now().UTC()
+	}
+	sw, err := newSnapshotWriter(w, header)
+	if err != nil {
+		return err
+	}
+	for _, id := range g.NodeIDs() {
+		if err := sw.writeNode(id, g.nodes[id]); err != nil {
+			return err
+		}
+	}
+	for _, edge := range g.edges {
+		if err := sw.writeEdge(edge); err != nil {
+			return err
+		}
+	}
+	return sw.close()
+}
+
+// LoadSnapshot reads a snapshot written by TakeSnapshot or WriteSnapshot into an
+// in-memory graph.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	snapshot := &Snapshot{Graph: NewGraph()}
+	decoder := json.NewDecoder(gz)
+	for line := 1; ; line++ {
+		var record snapshotRecord
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode snapshot record %d: %w", line, err)
+		}
+
+		switch {
+		case record.Type == "header" && record.Header != nil:
+			if record.Header.Version > SnapshotVersion {
+				return nil, fmt.Errorf("unsupported snapshot version: %d", record.Header.Version)
+			}
+			snapshot.Header = *record.Header
+		case record.Type == "node" && record.Node != nil:
+			snapshot.Graph.AddNode(record.ID, *record.Node)
+		case record.Type == "edge" && record.Edge != nil:
+			snapshot.Graph.AddEdge(*record.Edge)
+		default:
+			return nil, fmt.Errorf("invalid snapshot record %d of type %q", line, record.Type)
+		}
+	}
+	if snapshot.Header.Version == 0 {
+		return nil, errors.New("snapshot has no header")
+	}
+	snapshot.Header.Nodes, snapshot.Header.Edges = snapshot.Graph.NodeCount(), snapshot.Graph.EdgeCount()
+	return snapshot, nil
+}
+
+// genericIngestNode and the types below follow the BloodHound generic (OpenGraph) ingest format.
+type genericIngestNode struct {
+	ID         string                 `json:"id"`
+	Kinds      []string               `json:"kinds"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type genericIngestEndpoint struct {
+	Value   string `json:"value"`
+	MatchBy string `json:"match_by"`
+}
+
+type genericIngestEdge struct {
+	Kind       string                 `json:"kind"`
+	Start      genericIngestEndpoint  `json:"start"`
+	End        genericIngestEndpoint  `json:"end"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type genericIngestData struct {
+	Graph struct {
+		Nodes []genericIngestNode `json:"nodes"`
+		Edges []genericIngestEdge `json:"edges"`
+	} `json:"graph"`
+}
+
+// WriteGenericIngest writes the snapshot as a generic ingest file that can be uploaded to
+// a fresh instance. Nodes are identified by Object ID; edges between nodes without one
+// are dropped, as are nested property values the ingest format cannot hold.
+func (s *Snapshot) WriteGenericIngest(w io.Writer) error {
+	var data genericIngestData
+	data.Graph.Nodes = []genericIngestNode{}
+	data.Graph.Edges = []genericIngestEdge{}
+
+	for _, id := range s.Graph.NodeIDs() {
+		node := s.Graph.nodes[id]
+		objectID := objectIDOf(node)
+		if objectID == "" {
+			continue
+		}
+		kinds := node.Kinds
+		if len(kinds) == 0 && node.Kind != "" {
+			kinds = []string{node.Kind}
+		}
+		properties := ingestProperties(node.Properties)
+		if _, ok := properties["name"]; !ok && node.Name != "" {
+			properties["name"] = node.Name
+		}
+		data.Graph.Nodes = append(data.Graph.Nodes, genericIngestNode{ID: objectID, Kinds: kinds, Properties: properties})
+	}
+
+	for _, edge := range s.Graph.edges {
+		start, end := objectIDOf(s.Graph.nodes[edge.Source]), objectIDOf(s.Graph.nodes[edge.Target])
+		if start == "" || end == "" {
+			continue
+		}
+		data.Graph.Edges = append(data.Graph.Edges, genericIngestEdge{
+			Kind:       string(edge.EdgeKind()),
+			Start:      genericIngestEndpoint{Value: start, MatchBy: "id"},
+			End:        genericIngestEndpoint{Value: end, MatchBy: "id"},
+			Properties: ingestProperties(edge.Properties),
+		})
+	}
+
+	return json.NewEncoder(w).Encode(data)
+}
+
+// ingestProperties copies the properties the generic ingest format accepts: primitives
+// and arrays of primitives.
+func ingestProperties(properties map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		switch v := value.(type) {
+		case nil, map[string]interface{}:
+			continue
+		case []interface{}:
+			if !primitiveValues(v) {
+				continue
+			}
+		}
+		result[key] = value
+	}
+	return result
+}
+
+func primitiveValues(values []interface{}) bool {
+	for _, value := range values {
+		switch value.(type) {
+		case string, bool, float64, json.Number:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IngestSnapshot uploads the snapshot to the instance as generic ingest data through a
+// file upload job.
+func (c *Client) IngestSnapshot(ctx context.Context, s *Snapshot) error {
+	var buf bytes.Buffer
+	if err := s.WriteGenericIngest(&buf); err != nil {
+		return fmt.Errorf("failed to encode snapshot for ingest: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	job, err := c.StartFileUploadJob()
+	if err != nil {
+		return err
+	}
+	if err := c.UploadFile(job.ID, buf.Bytes(), "application/json"); err != nil {
+		return err
+	}
+	return c.EndFileUploadJob(job.ID)
+}
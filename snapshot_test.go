@@ -0,0 +1,177 @@
+package bloodhound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	g := withNodeProperties(testGraph(), "u", map[string]interface{}{
+		"enabled": true, "serviceprincipalnames": []interface{}{"HTTP/web"}, "nested": map[string]interface{}{"a": 1.0}})
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, g, SnapshotHeader{Server: "https://bh.example"}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := LoadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Header.Version != SnapshotVersion || snapshot.Header.Server != "https://bh.example" {
+		t.Errorf("unexpected header: %+v", snapshot.Header)
+	}
+	if snapshot.Header.Nodes != g.NodeCount() || snapshot.Header.Edges != g.EdgeCount() {
+		t.Errorf("got %d nodes and %d edges, want %d and %d", snapshot.Header.Nodes, snapshot.Header.Edges, g.NodeCount(), g.EdgeCount())
+	}
+	if node, _ := snapshot.Graph.Node("u"); node.Properties["enabled"] != true {
+		t.Errorf("node properties were not kept: %+v", node)
+	}
+	if id, ok := snapshot.Graph.NodeIDForObject("S-1-5-21-1-512"); !ok || id != "d" {
+		t.Errorf("NodeIDForObject = %q, %v", id, ok)
+	}
+
+	var ingest bytes.Buffer
+	if err := snapshot.WriteGenericIngest(&ingest); err != nil {
+		t.Fatal(err)
+	}
+	var data genericIngestData
+	if err := json.Unmarshal(ingest.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Graph.Nodes) != 5 || len(data.Graph.Edges) != 6 {
+		t.Fatalf("unexpected ingest counts: %d nodes, %d edges", len(data.Graph.Nodes), len(data.Graph.Edges))
+	}
+	for _, node := range data.Graph.Nodes {
+		if node.ID != "S-1-5-21-1-1104" {
+			continue
+		}
+		if _, ok := node.Properties["nested"]; ok {
+			t.Error("nested property was not dropped")
+		}
+		if len(node.Kinds) != 2 || node.Properties["name"] != "ALICE@CORP.LOCAL" {
+			t.Errorf("unexpected ingest node: %+v", node)
+		}
+	}
+	if edge := data.Graph.Edges[0]; edge.Start.MatchBy != "id" || edge.Start.Value == "" {
+		t.Errorf("unexpected ingest edge: %+v", edge)
+	}
+}
+
+// snapshotServer pages through nodes and edges by internal ID like BloodHound, with a 404
+// for queries without results. Nodes get internal IDs in the order of objectIDs, which
+// need not be the byte order of the Object IDs.
+func snapshotServer(t *testing.T, objectIDs []string, edges [][2]string) (*Client, *int) {
+	nodeQuery := regexp.MustCompile(`id\(n\) > (\d+) AND n\.objectid IS NOT NULL RETURN n ORDER BY id\(n\) LIMIT (\d+)$`)
+	edgeQuery := regexp.MustCompile(`id\(s\) > (\d+) AND id\(s\) <= (\d+) RETURN p ORDER BY id\(r\) SKIP (\d+) LIMIT (\d+)$`)
+	internalID := map[string]int{}
+	for i, objectID := range objectIDs {
+		internalID[objectID] = i + 1
+	}
+	node := func(objectID string) string {
+		return fmt.Sprintf(`"%d": {"label": %q, "kind": "User", "objectId": %q}`, internalID[objectID], objectID+"@CORP.LOCAL", objectID)
+	}
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	queries := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/graphs/cypher", func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		var query CypherQuery
+		json.NewDecoder(r.Body).Decode(&query)
+
+		var nodes, rels []string
+		if m := nodeQuery.FindStringSubmatch(query.Query); m != nil {
+			for i, objectID := range objectIDs {
+				if i+1 > atoi(m[1]) && len(nodes) < atoi(m[2]) {
+					nodes = append(nodes, node(objectID))
+				}
+			}
+		} else if m := edgeQuery.FindStringSubmatch(query.Query); m != nil {
+			skip, limit := atoi(m[3]), atoi(m[4])
+			for _, edge := range edges {
+				if start := internalID[edge[0]]; start > atoi(m[1]) && start <= atoi(m[2]) {
+					if skip > 0 {
+						skip--
+						continue
+					}
+					if len(rels) < limit {
+						nodes = append(nodes, node(edge[0]), node(edge[1]))
+						rels = append(rels, fmt.Sprintf(`{"source": "%d", "target": "%d", "kind": "MemberOf"}`, internalID[edge[0]], internalID[edge[1]]))
+					}
+				}
+			}
+		} else {
+			t.Errorf("unexpected snapshot query: %s", query.Query)
+		}
+
+		if len(nodes) == 0 {
+			writeTestJSON(w, http.StatusNotFound, `{"errors": [{"message": "resource not found"}]}`)
+			return
+		}
+		writeTestJSON(w, http.StatusOK, fmt.Sprintf(`{"data": {"nodes": {%s}, "edges": [%s]}}`, strings.Join(nodes, ", "), strings.Join(rels, ", ")))
+	})
+	return newTestClient(t, mux), &queries
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	tests := []struct {
+		name      string
+		objectIDs []string
+		edges     [][2]string
+		pageSize  int
+		queries   int
+	}{
+		{name: "empty instance", pageSize: 2, queries: 1},
+		// Nodes, then edges of the first page; nodes, then edges of the second, partial page.
+		{name: "partial last page", objectIDs: []string{"S-1", "S-2", "S-3"}, edges: [][2]string{{"S-1", "S-3"}}, pageSize: 2, queries: 4},
+		// The page after the last one comes back empty, as do the edges of the second page.
+		{name: "exact multiple of the page size", objectIDs: []string{"S-1", "S-2", "S-3", "S-4"}, edges: [][2]string{{"S-2", "S-1"}}, pageSize: 2, queries: 5},
+		// Internal IDs do not follow the byte order of the Object IDs.
+		{name: "object IDs out of order", objectIDs: []string{"S-10", "S-9", "S-1"}, edges: [][2]string{{"S-9", "S-10"}, {"S-1", "S-9"}}, pageSize: 2, queries: 4},
+		// The hub's five edges take three pages of two.
+		{name: "hub fan-out", objectIDs: []string{"HUB", "S-1", "S-2", "S-3", "S-4", "S-5"},
+			edges: [][2]string{{"HUB", "S-1"}, {"HUB", "S-2"}, {"HUB", "S-3"}, {"HUB", "S-4"}, {"HUB", "S-5"}}, pageSize: 2, queries: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, queries := snapshotServer(t, tt.objectIDs, tt.edges)
+
+			var buf bytes.Buffer
+			header, err := client.TakeSnapshot(context.Background(), &buf, SnapshotOptions{PageSize: tt.pageSize})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if header.Nodes != len(tt.objectIDs) || header.Edges != len(tt.edges) || *queries != tt.queries {
+				t.Errorf("got %d nodes, %d edges in %d queries; want %d, %d in %d",
+					header.Nodes, header.Edges, *queries, len(tt.objectIDs), len(tt.edges), tt.queries)
+			}
+
+			snapshot, err := LoadSnapshot(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if snapshot.Graph.NodeCount() != len(tt.objectIDs) || snapshot.Graph.EdgeCount() != len(tt.edges) {
+				t.Errorf("loaded %d nodes and %d edges", snapshot.Graph.NodeCount(), snapshot.Graph.EdgeCount())
+			}
+		})
+	}
+
+	client, _ := snapshotServer(t, []string{"S-1"}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.TakeSnapshot(ctx, io.Discard, SnapshotOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("TakeSnapshot() with a cancelled context = %v", err)
+	}
+}
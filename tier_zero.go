@@ -0,0 +1,214 @@
+package bloodhound
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TierZeroSelectorName is the selector name used for members added through the client.
+const TierZeroSelectorName = "bloodhound-go"
+
+// TierZeroInventory holds the current Tier Zero members, grouped by kind and by domain.
+// Domains are keyed by environment ID (domain SID or Azure tenant ID). SelectorNames maps
+// the upper-case Object ID of every custom member to the name of the selector that added it.
+type TierZeroInventory struct {
+	AssetGroupID  int
+	Members       []AssetGroupMember
+	ByKind        map[string][]AssetGroupMember
+	ByDomain      map[string][]AssetGroupMember
+	SelectorNames map[string]string
+}
+
+// TierZeroDesiredState declares the objects that should be custom Tier Zero members.
+// Only custom members are managed: objects selected by BloodHound's built-in
+// selectors are never proposed for removal.
+type TierZeroDesiredState struct {
+	ObjectIDs []string
+}
+
+// TierZeroChange is a single planned change to the Tier Zero asset group.
+type TierZeroChange struct {
+	ObjectID     string
+	Name         string
+	Kind         string
+	Action       string // "add" or "remove"
+	SelectorName string
+}
+
+// TierZeroPlan is the set of changes needed to bring Tier Zero to the desired state.
+// Skipped lists the custom members that should be removed but whose selector is unknown;
+// they are left in place, since BloodHound only removes a member through its own selector.
+type TierZeroPlan struct {
+	AssetGroupID int
+	Add          []TierZeroChange
+	Remove       []TierZeroChange
+	Skipped      []TierZeroChange
+	Unchanged    int
+}
+
+// ListTierZeroMembers fetches the members of the Tier Zero asset group.
+func (c *Client) ListTierZeroMembers() (*TierZeroInventory, error) {
+	return c.listTierZeroMembers(context.Background())
+}
+
+func (c *Client) listTierZeroMembers(ctx context.Context) (*TierZeroInventory, error) {
+	group, err := c.getAssetGroupByTag(ctx, TagTierZero)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.listAllAssetGroupMembers(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &TierZeroInventory{
+		AssetGroupID:  group.ID,
+		Members:       members,
+		ByKind:        map[string][]AssetGroupMember{},
+		ByDomain:      map[string][]AssetGroupMember{},
+		SelectorNames: selectorNames(group),
+	}
+	for _, member := range members {
+		inventory.ByKind[member.PrimaryKind] = append(inventory.ByKind[member.PrimaryKind], member)
+		inventory.ByDomain[member.EnvironmentID] = append(inventory.ByDomain[member.EnvironmentID], member)
+	}
+	return inventory, nil
+}
+
+// AddTierZeroMembers adds the given objects to Tier Zero as custom members.
+func (c *Client) AddTierZeroMembers(objectIDs ...string) error {
+	return c.updateTierZeroMembers("add", objectIDs)
+}
+
+// RemoveTierZeroMembers removes the given custom members from Tier Zero, through the
+// selector that added each of them. Nothing is removed if any of them has no selector.
+func (c *Client) RemoveTierZeroMembers(objectIDs ...string) error {
+	return c.updateTierZeroMembers("remove", objectIDs)
+}
+
+func (c *Client) updateTierZeroMembers(action string, objectIDs []string) error {
+	if len(objectIDs) == 0 {
+		return nil
+	}
+	group, err := c.GetAssetGroupByTag(TagTierZero)
+	if err != nil {
+		return err
+	}
+	names := selectorNames(group)
+	updates := make([]AssetGroupSelectorUpdate, 0, len(objectIDs))
+	for _, objectID := range objectIDs {
+		selectorName := TierZeroSelectorName
+		if action == "remove" {
+			selectorName = names[strings.ToUpper(objectID)]
+			if selectorName == "" {
+				return fmt.Errorf("no tier zero selector found for %s", objectID)
+			}
+		}
+		updates = append(updates, AssetGroupSelectorUpdate{SelectorName: selectorName, SID: objectID, Action: action})
+	}
+	return c.UpdateAssetGroupMembers(group.ID, updates)
+}
+
+// selectorNames maps the upper-case Object ID selected by each of the group's selectors to the selector name.
+func selectorNames(group *AssetGroup) map[string]string {
+	names := make(map[string]string, len(group.Selectors))
+	for _, selector := range group.Selectors {
+		names[strings.ToUpper(selector.Selector)] = selector.Name
+	}
+	return names
+}
+
+// PlanTierZero compares the current Tier Zero members with the desired state and
+// returns the changes needed to reconcile them. Nothing is changed on the server.
+func (c *Client) PlanTierZero(desired TierZeroDesiredState) (*TierZeroPlan, error) {
+	inventory, err := c.ListTierZeroMembers()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.Plan(desired), nil
+}
+
+// Plan computes the changes needed to move this inventory to the desired state.
+func (inv *TierZeroInventory) Plan(desired TierZeroDesiredState) *TierZeroPlan {
+	plan := &TierZeroPlan{AssetGroupID: inv.AssetGroupID}
+
+	wanted := make(map[string]bool, len(desired.ObjectIDs))
+	for _, objectID := range desired.ObjectIDs {
+		wanted[strings.ToUpper(objectID)] = true
+	}
+
+	current := make(map[string]bool, len(inv.Members))
+	for _, member := range inv.Members {
+		key := strings.ToUpper(member.ObjectID)
+		current[key] = true
+		switch {
+		case wanted[key]:
+			plan.Unchanged++
+		case member.CustomMember:
+			change := TierZeroChange{ObjectID: member.ObjectID, Name: member.Name, Kind: member.PrimaryKind, Action: "remove", SelectorName: inv.SelectorNames[key]}
+			if change.SelectorName == "" {
+				plan.Skipped = append(plan.Skipped, change)
+			} else {
+				plan.Remove = append(plan.Remove, change)
+			}
+		}
+	}
+
+	for _, objectID := range desired.ObjectIDs {
+		if !current[strings.ToUpper(objectID)] {
+			plan.Add = append(plan.Add, TierZeroChange{ObjectID: objectID, Action: "add", SelectorName: TierZeroSelectorName})
+			current[strings.ToUpper(objectID)] = true
+		}
+	}
+
+	sort.Slice(plan.Add, func(i, j int) bool { return plan.Add[i].ObjectID < plan.Add[j].ObjectID })
+	sort.Slice(plan.Remove, func(i, j int) bool { return plan.Remove[i].ObjectID < plan.Remove[j].ObjectID })
+	sort.Slice(plan.Skipped, func(i, j int) bool { return plan.Skipped[i].ObjectID < plan.Skipped[j].ObjectID })
+	return plan
+}
+
+// HasChanges reports whether applying the plan would change anything.
+func (p *TierZeroPlan) HasChanges() bool {
+	return len(p.Add) > 0 || len(p.Remove) > 0
+}
+
+// String renders the plan as dry-run output, one change per line.
+func (p *TierZeroPlan) String() string {
+	var b strings.Builder
+	for _, change := range p.Add {
+		fmt.Fprintf(&b, "  + %s\n", change.ObjectID)
+	}
+	for _, change := range p.Remove {
+		fmt.Fprintf(&b, "  - %s (%s, %s)\n", change.ObjectID, change.Name, change.Kind)
+	}
+	for _, change := range p.Skipped {
+		fmt.Fprintf(&b, "  ! %s (%s, %s): selector unknown, not removed\n", change.ObjectID, change.Name, change.Kind)
+	}
+	fmt.Fprintf(&b, "Plan: %d to add, %d to remove, %d unchanged.\n", len(p.Add), len(p.Remove), p.Unchanged)
+	return b.String()
+}
+
+// ApplyTierZeroPlan applies a plan produced by PlanTierZero.
+func (c *Client) ApplyTierZeroPlan(plan *TierZeroPlan) error {
+	if !plan.HasChanges() {
+		return nil
+	}
+	updates := make([]AssetGroupSelectorUpdate, 0, len(plan.Add)+len(plan.Remove))
+	for _, change := range append(append([]TierZeroChange{}, plan.Add...), plan.Remove...) {
+		selectorName := change.SelectorName
+		if selectorName == "" {
+			if change.Action == "remove" {
+				return fmt.Errorf("no tier zero selector found for %s", change.ObjectID)
+			}
+			selectorName = TierZeroSelectorName
+		}
+		updates = append(updates, AssetGroupSelectorUpdate{SelectorName: selectorName, SID: change.ObjectID, Action: change.Action})
+	}
+	if err := c.UpdateAssetGroupMembers(plan.AssetGroupID, updates); err != nil {
+		return fmt.Errorf("failed to apply tier zero plan: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package bloodhound
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTierZeroInventoryPlan(t *testing.T) {
+	inventory := &TierZeroInventory{
+		AssetGroupID: 1,
+		Members: []AssetGroupMember{
+			{ObjectID: "S-1-5-21-1-512", Name: "DOMAIN ADMINS@CORP.LOCAL", PrimaryKind: "Group"},
+			{ObjectID: "S-1-5-21-1-1104", Name: "ALICE@CORP.LOCAL", PrimaryKind: "User", CustomMember: true},
+			{ObjectID: "S-1-5-21-1-1105", Name: "BOB@CORP.LOCAL", PrimaryKind: "User", CustomMember: true},
+			{ObjectID: "S-1-5-21-1-1106", Name: "CAROL@CORP.LOCAL", PrimaryKind: "User", CustomMember: true},
+		},
+		SelectorNames: map[string]string{
+			"S-1-5-21-1-1104": TierZeroSelectorName,
+			"S-1-5-21-1-1105": "Manual",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		desired   []string
+		add       []TierZeroChange
+		remove    []TierZeroChange
+		skipped   []TierZeroChange
+		unchanged int
+	}{
+		{
+			name:      "in sync",
+			desired:   []string{"S-1-5-21-1-1104", "s-1-5-21-1-1105", "S-1-5-21-1-1106"},
+			unchanged: 3,
+		},
+		{
+			name:      "add",
+			desired:   []string{"S-1-5-21-1-1104", "S-1-5-21-1-1105", "S-1-5-21-1-1106", "S-1-5-21-1-1107", "S-1-5-21-1-1107"},
+			add:       []TierZeroChange{{ObjectID: "S-1-5-21-1-1107", Action: "add", SelectorName: TierZeroSelectorName}},
+			unchanged: 3,
+		},
+		{
+			name:    "remove through each member's selector",
+			desired: []string{"S-1-5-21-1-1106"},
+			remove: []TierZeroChange{
+				{ObjectID: "S-1-5-21-1-1104", Name: "ALICE@CORP.LOCAL", Kind: "User", Action: "remove", SelectorName: TierZeroSelectorName},
+				{ObjectID: "S-1-5-21-1-1105", Name: "BOB@CORP.LOCAL", Kind: "User", Action: "remove", SelectorName: "Manual"},
+			},
+			unchanged: 1,
+		},
+		{
+			name:    "skip members without a known selector",
+			desired: []string{"S-1-5-21-1-1104", "S-1-5-21-1-1105"},
+			skipped: []TierZeroChange{
+				{ObjectID: "S-1-5-21-1-1106", Name: "CAROL@CORP.LOCAL", Kind: "User", Action: "remove"},
+			},
+			unchanged: 2,
+		},
+		{
+			name:    "built-in members are kept",
+			desired: nil,
+			remove: []TierZeroChange{
+				{ObjectID: "S-1-5-21-1-1104", Name: "ALICE@CORP.LOCAL", Kind: "User", Action: "remove", SelectorName: TierZeroSelectorName},
+				{ObjectID: "S-1-5-21-1-1105", Name: "BOB@CORP.LOCAL", Kind: "User", Action: "remove", SelectorName: "Manual"},
+			},
+			skipped: []TierZeroChange{
+				{ObjectID: "S-1-5-21-1-1106", Name: "CAROL@CORP.LOCAL", Kind: "User", Action: "remove"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := inventory.Plan(TierZeroDesiredState{ObjectIDs: tt.desired})
+			if plan.AssetGroupID != 1 || plan.Unchanged != tt.unchanged {
+				t.Errorf("AssetGroupID = %d, Unchanged = %d, want 1, %d", plan.AssetGroupID, plan.Unchanged, tt.unchanged)
+			}
+			for _, check := range []struct {
+				name      string
+				got, want []TierZeroChange
+			}{{"Add", plan.Add, tt.add}, {"Remove", plan.Remove, tt.remove}, {"Skipped", plan.Skipped, tt.skipped}} {
+				if len(check.got) != 0 || len(check.want) != 0 {
+					if !reflect.DeepEqual(check.got, check.want) {
+						t.Errorf("%s = %+v, want %+v", check.name, check.got, check.want)
+					}
+				}
+			}
+			if plan.HasChanges() != (len(tt.add)+len(tt.remove) > 0) {
+				t.Errorf("HasChanges() = %v", plan.HasChanges())
+			}
+		})
+	}
+}
+
+func TestApplyTierZeroPlan(t *testing.T) {
+	var got []AssetGroupSelectorUpdate
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v2/asset-groups/1/selectors" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode updates: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	plan := &TierZeroPlan{
+		AssetGroupID: 1,
+		Add:          []TierZeroChange{{ObjectID: "S-1-5-21-1-1107", Action: "add", SelectorName: TierZeroSelectorName}},
+		Remove:       []TierZeroChange{{ObjectID: "S-1-5-21-1-1105", Action: "remove", SelectorName: "Manual"}},
+		Skipped:      []TierZeroChange{{ObjectID: "S-1-5-21-1-1106", Action: "remove"}},
+	}
+	if err := client.ApplyTierZeroPlan(plan); err != nil {
+		t.Fatalf("ApplyTierZeroPlan returned an error: %v", err)
+	}
+	want := []AssetGroupSelectorUpdate{
+		{SelectorName: TierZeroSelectorName, SID: "S-1-5-21-1-1107", Action: "add"},
+		{SelectorName: "Manual", SID: "S-1-5-21-1-1105", Action: "remove"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("updates = %+v, want %+v", got, want)
+	}
+
+	plan = &TierZeroPlan{AssetGroupID: 1, Remove: []TierZeroChange{{ObjectID: "S-1-5-21-1-1106", Action: "remove"}}}
+	if err := client.ApplyTierZeroPlan(plan); err == nil {
+		t.Error("expected an error for a removal without a selector")
+	}
+}
+
+func TestListAllAssetGroupMembersWithoutCount(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var members []string
+		for i := skip; i < skip+limit && i < 750; i++ {
+			members = append(members, fmt.Sprintf(`{"object_id": "S-1-5-21-1-%d"}`, i))
+		}
+		// The count is omitted, as some servers do.
+		writeTestJSON(w, http.StatusOK, `{"data": {"members": [`+strings.Join(members, ",")+`]}}`)
+	}))
+
+	members, err := client.ListAllAssetGroupMembers(1)
+	if err != nil || len(members) != 750 {
+		t.Errorf("got %d members (err %v), want 750", len(members), err)
+	}
+}
@@ -0,0 +1,222 @@
+// Code generated by bhgen from the BloodHound OpenAPI document. DO NOT EDIT.
+
+package bloodhound
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// APIAuditLogs is the api.AuditLogs schema.
+type APIAuditLogs struct {
+	Logs []APIModelAuditLog `json:"logs,omitempty"`
+}
+
+// APIVersion is the api.Version schema.
+type APIVersion struct {
+	API           *APIVersionDetails `json:"API,omitempty"`
+	ServerVersion string             `json:"server_version,omitempty"`
+}
+
+// APIVersionDetails is the api.VersionDetails schema.
+type APIVersionDetails struct {
+	CurrentVersion    string `json:"current_version,omitempty"`
+	DeprecatedVersion string `json:"deprecated_version,omitempty"`
+}
+
+// APIAppcfgFeatureFlag is the appcfg.FeatureFlag schema.
+type APIAppcfgFeatureFlag struct {
+	Description   string `json:"description,omitempty"`
+	Enabled       bool   `json:"enabled,omitempty"`
+	ID            int32  `json:"id,omitempty"`
+	Key           string `json:"key,omitempty"`
+	Name          string `json:"name,omitempty"`
+	UserUpdatable bool   `json:"user_updatable,omitempty"`
+}
+
+// APIAppcfgParameter is the appcfg.Parameter schema.
+type APIAppcfgParameter struct {
+	CreatedAt   time.Time              `json:"created_at,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	ID          int32                  `json:"id,omitempty"`
+	Key         string                 `json:"key,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
+	Value       map[string]interface{} `json:"value,omitempty"`
+}
+
+// APIModelAuditLog is the model.AuditLog schema.
+type APIModelAuditLog struct {
+	Action          string                 `json:"action,omitempty"`
+	ActorEmail      string                 `json:"actor_email,omitempty"`
+	ActorID         string                 `json:"actor_id,omitempty"`
+	ActorName       string                 `json:"actor_name,omitempty"`
+	CommitID        string                 `json:"commit_id,omitempty"`
+	CreatedAt       time.Time              `json:"created_at,omitempty"`
+	Fields          map[string]interface{} `json:"fields,omitempty"`
+	ID              int64                  `json:"id,omitempty"`
+	RequestID       string                 `json:"request_id,omitempty"`
+	SourceIpAddress string                 `json:"source_ip_address,omitempty"`
+	Status          string                 `json:"status,omitempty"`
+}
+
+// APIModelCollectorManifest is the model.CollectorManifest schema.
+type APIModelCollectorManifest struct {
+	Latest   string                     `json:"latest,omitempty"`
+	Versions []APIModelCollectorVersion `json:"versions,omitempty"`
+}
+
+// APIModelCollectorVersion is the model.CollectorVersion schema.
+type APIModelCollectorVersion struct {
+	Deprecated bool   `json:"deprecated,omitempty"`
+	Sha256sum  string `json:"sha256sum,omitempty"`
+	Version    string `json:"version,omitempty"`
+}
+
+// APIModelDatapipeStatus is the model.DatapipeStatus schema.
+type APIModelDatapipeStatus struct {
+	LastAnalysisRunAt      time.Time `json:"last_analysis_run_at,omitempty"`
+	LastCompleteAnalysisAt time.Time `json:"last_complete_analysis_at,omitempty"`
+	Status                 string    `json:"status,omitempty"`
+	UpdatedAt              time.Time `json:"updated_at,omitempty"`
+}
+
+// RawRequestAnalysis calls PUT /api/v2/analysis.
+//
+// Request analysis
+func (c *Client) RawRequestAnalysis(ctx context.Context, query url.Values) error {
+	apiUrl := c.baseURL.JoinPath("/api/v2/analysis")
+	apiUrl.RawQuery = query.Encode()
+	return c.doJSON(ctx, http.MethodPut, apiUrl, nil, nil)
+}
+
+// APIListAuditLogsResponse is the response body of ListAuditLogs.
+type APIListAuditLogsResponse struct {
+	Count int64         `json:"count,omitempty"`
+	Data  *APIAuditLogs `json:"data,omitempty"`
+	Limit int64         `json:"limit,omitempty"`
+	Skip  int64         `json:"skip,omitempty"`
+}
+
+// RawListAuditLogs calls GET /api/v2/audit.
+//
+// List audit logs
+func (c *Client) RawListAuditLogs(ctx context.Context, query url.Values) (*APIListAuditLogsResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/audit")
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIListAuditLogsResponse)
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIGetCollectorManifestResponse is the response body of GetCollectorManifest.
+type APIGetCollectorManifestResponse struct {
+	Data *APIModelCollectorManifest `json:"data,omitempty"`
+}
+
+// RawGetCollectorManifest calls GET /api/v2/collectors/{collector_type}.
+//
+// Get collector manifest
+func (c *Client) RawGetCollectorManifest(ctx context.Context, collectorType string, query url.Values) (*APIGetCollectorManifestResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/collectors/", collectorType)
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIGetCollectorManifestResponse)
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIListAppConfigParamsResponse is the response body of ListAppConfigParams.
+type APIListAppConfigParamsResponse struct {
+	Data []APIAppcfgParameter `json:"data,omitempty"`
+}
+
+// RawListAppConfigParams calls GET /api/v2/config.
+//
+// List application config parameters
+func (c *Client) RawListAppConfigParams(ctx context.Context, query url.Values) (*APIListAppConfigParamsResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/config")
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIListAppConfigParamsResponse)
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIGetDatapipeStatusResponse is the response body of GetDatapipeStatus.
+type APIGetDatapipeStatusResponse struct {
+	Data *APIModelDatapipeStatus `json:"data,omitempty"`
+}
+
+// RawGetDatapipeStatus calls GET /api/v2/datapipe/status.
+//
+// Get datapipe status
+func (c *Client) RawGetDatapipeStatus(ctx context.Context, query url.Values) (*APIGetDatapipeStatusResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/datapipe/status")
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIGetDatapipeStatusResponse)
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIGetFeatureFlagsResponse is the response body of GetFeatureFlags.
+type APIGetFeatureFlagsResponse struct {
+	Data []APIAppcfgFeatureFlag `json:"data,omitempty"`
+}
+
+// RawGetFeatureFlags calls GET /api/v2/features.
+//
+// List feature flags
+func (c *Client) RawGetFeatureFlags(ctx context.Context, query url.Values) (*APIGetFeatureFlagsResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/features")
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIGetFeatureFlagsResponse)
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIToggleFeatureFlagResponse is the response body of ToggleFeatureFlag.
+type APIToggleFeatureFlagResponse struct {
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// RawToggleFeatureFlag calls PUT /api/v2/features/{feature_id}/toggle.
+//
+// Toggle feature flag
+func (c *Client) RawToggleFeatureFlag(ctx context.Context, featureID string, query url.Values) (*APIToggleFeatureFlagResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/v2/features/", featureID, "/toggle")
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIToggleFeatureFlagResponse)
+	if err := c.doJSON(ctx, http.MethodPut, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIGetAPIVersionResponse is the response body of GetAPIVersion.
+type APIGetAPIVersionResponse struct {
+	Data *APIVersion `json:"data,omitempty"`
+}
+
+// RawGetAPIVersion calls GET /api/version.
+//
+// Get API version
+func (c *Client) RawGetAPIVersion(ctx context.Context, query url.Values) (*APIGetAPIVersionResponse, error) {
+	apiUrl := c.baseURL.JoinPath("/api/version")
+	apiUrl.RawQuery = query.Encode()
+	out := new(APIGetAPIVersionResponse)
+	if err := c.doJSON(ctx, http.MethodGet, apiUrl, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}